@@ -7,6 +7,7 @@ package builder
 import (
 	sql2 "database/sql"
 	"fmt"
+	"reflect"
 	"sort"
 )
 
@@ -29,13 +30,22 @@ const (
 	ORACLE   = "oracle"
 )
 
+// versions of dialects which support OFFSET ... FETCH pagination
+const (
+	MSSQL2012 = "mssql2012"
+	ORACLE12C = "oracle12c"
+)
+
 type join struct {
 	joinType  string
-	joinTable string
+	joinTable interface{}
 	joinCond  Cond
+	using     Using
+	lateral   bool
 }
 
 type union struct {
+	op        string
 	unionType string
 	builder   *Builder
 }
@@ -45,6 +55,11 @@ type limit struct {
 	offset int
 }
 
+type upsert struct {
+	conflictCols []string
+	updates      []Eq
+}
+
 // Builder describes a SQL statement
 type Builder struct {
 	optype
@@ -55,15 +70,26 @@ type Builder struct {
 	subQuery   *Builder
 	cond       Cond
 	selects    []string
+	selectArgs []interface{}
 	joins      []join
 	unions     []union
 	limitation *limit
+	quoted     bool
+	alias      string
+	lock       *lock
 	insertCols []string
 	insertVals []interface{}
+	batchRows  [][]interface{}
+	ctes       []cte
+	upsert     *upsert
+	returning  *returning
 	updates    []Eq
-	orderBy    string
-	groupBy    string
-	having     string
+	orderBy    []Order
+	groupBy    []Grouping
+	having     Cond
+	all        bool
+	// the struct of UpdateStruct has no pk fields
+	missingPk bool
 }
 
 // Dialect sets the db dialect of Builder.
@@ -107,6 +133,21 @@ func (b *Builder) Where(cond Cond) *Builder {
 	return b
 }
 
+// All allows UPDATE or DELETE without conditions, which modifies all rows of the table
+func (b *Builder) All() *Builder {
+	b.all = true
+	return b
+}
+
+// checkWhere checks that UPDATE or DELETE has conditions unless All is called, joins are not
+// taken as conditions since they may match every row
+func (b *Builder) checkWhere() error {
+	if b.all || (b.cond != nil && b.cond.IsValid()) {
+		return nil
+	}
+	return ErrNoWhereCondition
+}
+
 // From sets from subject(can be a table name in string or a builder pointer) and its alias
 func (b *Builder) From(subject interface{}, alias ...string) *Builder {
 	switch subject.(type) {
@@ -115,6 +156,8 @@ func (b *Builder) From(subject interface{}, alias ...string) *Builder {
 
 		if len(alias) > 0 {
 			b.from = alias[0]
+		} else if len(b.subQuery.alias) > 0 {
+			b.from = b.subQuery.alias
 		} else {
 			b.isNested = true
 		}
@@ -143,13 +186,18 @@ func (b *Builder) Into(tableName string) *Builder {
 	return b
 }
 
-// Join sets join table and conditions
-func (b *Builder) Join(joinType, joinTable string, joinCond interface{}) *Builder {
+// Join sets join table and conditions, the table could be a table name or a *Builder
+// named by As, and the condition could be a Cond, a string, Using or nil
+func (b *Builder) Join(joinType string, joinTable interface{}, joinCond interface{}) *Builder {
 	switch joinCond.(type) {
 	case Cond:
-		b.joins = append(b.joins, join{joinType, joinTable, joinCond.(Cond)})
+		b.joins = append(b.joins, join{joinType: joinType, joinTable: joinTable, joinCond: joinCond.(Cond)})
 	case string:
-		b.joins = append(b.joins, join{joinType, joinTable, Expr(joinCond.(string))})
+		b.joins = append(b.joins, join{joinType: joinType, joinTable: joinTable, joinCond: Expr(joinCond.(string))})
+	case Using:
+		b.joins = append(b.joins, join{joinType: joinType, joinTable: joinTable, joinCond: NewCond(), using: joinCond.(Using)})
+	case nil:
+		b.joins = append(b.joins, join{joinType: joinType, joinTable: joinTable, joinCond: NewCond()})
 	}
 
 	return b
@@ -157,35 +205,7 @@ func (b *Builder) Join(joinType, joinTable string, joinCond interface{}) *Builde
 
 // Union sets union conditions
 func (b *Builder) Union(unionTp string, unionCond *Builder) *Builder {
-	var builder *Builder
-	if b.optype != unionType {
-		builder = &Builder{cond: NewCond()}
-		builder.optype = unionType
-		builder.dialect = b.dialect
-		builder.selects = b.selects
-
-		currentUnions := b.unions
-		// erase sub unions (actually append to new Builder.unions)
-		b.unions = nil
-
-		for e := range currentUnions {
-			currentUnions[e].builder.dialect = b.dialect
-		}
-
-		builder.unions = append(append(builder.unions, union{"", b}), currentUnions...)
-	} else {
-		builder = b
-	}
-
-	if unionCond != nil {
-		if unionCond.dialect == "" && builder.dialect != "" {
-			unionCond.dialect = builder.dialect
-		}
-
-		builder.unions = append(builder.unions, union{unionTp, unionCond})
-	}
-
-	return builder
+	return b.setOperation("UNION", unionTp, unionCond)
 }
 
 // Limit sets limitN condition
@@ -200,27 +220,27 @@ func (b *Builder) Limit(limitN int, offset ...int) *Builder {
 }
 
 // InnerJoin sets inner join
-func (b *Builder) InnerJoin(joinTable string, joinCond interface{}) *Builder {
+func (b *Builder) InnerJoin(joinTable, joinCond interface{}) *Builder {
 	return b.Join("INNER", joinTable, joinCond)
 }
 
 // LeftJoin sets left join SQL
-func (b *Builder) LeftJoin(joinTable string, joinCond interface{}) *Builder {
+func (b *Builder) LeftJoin(joinTable, joinCond interface{}) *Builder {
 	return b.Join("LEFT", joinTable, joinCond)
 }
 
 // RightJoin sets right join SQL
-func (b *Builder) RightJoin(joinTable string, joinCond interface{}) *Builder {
+func (b *Builder) RightJoin(joinTable, joinCond interface{}) *Builder {
 	return b.Join("RIGHT", joinTable, joinCond)
 }
 
 // CrossJoin sets cross join SQL
-func (b *Builder) CrossJoin(joinTable string, joinCond interface{}) *Builder {
+func (b *Builder) CrossJoin(joinTable, joinCond interface{}) *Builder {
 	return b.Join("CROSS", joinTable, joinCond)
 }
 
 // FullJoin sets full join SQL
-func (b *Builder) FullJoin(joinTable string, joinCond interface{}) *Builder {
+func (b *Builder) FullJoin(joinTable, joinCond interface{}) *Builder {
 	return b.Join("FULL", joinTable, joinCond)
 }
 
@@ -262,12 +282,13 @@ func (s insertColsSorter) Less(i, j int) bool {
 	return s.cols[i] < s.cols[j]
 }
 
-// Insert sets insert SQL
+// Insert sets insert SQL, which accepts Eq, []Eq, column names, or structs (and their slices)
+// whose columns are mapped by db tags
 func (b *Builder) Insert(eq ...interface{}) *Builder {
 	if len(eq) > 0 {
 		var paramType = -1
 		for _, e := range eq {
-			switch t := e.(type) {
+			switch t := structEq(e).(type) {
 			case Eq:
 				if paramType == -1 {
 					paramType = 0
@@ -287,6 +308,14 @@ func (b *Builder) Insert(eq ...interface{}) *Builder {
 					break
 				}
 				b.insertCols = append(b.insertCols, t)
+			case []Eq:
+				if paramType == -1 {
+					paramType = 2
+				}
+				if paramType != 2 {
+					break
+				}
+				b.insertEqRows(t)
 			}
 		}
 	}
@@ -322,6 +351,35 @@ func (b *Builder) Delete(conds ...Cond) *Builder {
 
 // WriteTo implements Writer interface
 func (b *Builder) WriteTo(w Writer) error {
+	// let the conditions know which dialect they are written in
+	if bw, ok := w.(*BytesWriter); ok && b.dialect != "" && bw.dialect != b.dialect {
+		dialect := bw.dialect
+		bw.dialect = b.dialect
+		defer func() {
+			bw.dialect = dialect
+		}()
+	}
+	if bw, ok := w.(*BytesWriter); ok && b.quoted && !bw.quoted {
+		bw.quoted = true
+		defer func() {
+			bw.quoted = false
+		}()
+	}
+
+	// MySQL and Oracle put WITH clause of INSERT in front of its SELECT part
+	if len(b.ctes) > 0 && !(b.optype == insertType && (b.family() == MYSQL || b.family() == ORACLE)) {
+		if err := b.withWriteTo(w); err != nil {
+			return err
+		}
+
+		// erase CTEs so that they won't be written again by nested builders
+		ctes := b.ctes
+		b.ctes = nil
+		defer func() {
+			b.ctes = ctes
+		}()
+	}
+
 	switch b.optype {
 	/*case condType:
 	return b.cond.WriteTo(w)*/
@@ -340,13 +398,30 @@ func (b *Builder) WriteTo(w Writer) error {
 	return ErrNotSupportType
 }
 
-// ToSQL convert a builder to SQL and args
+// ToSQL convert a builder to SQL and args, Params should be bound by Compile and Template.Bind
 func (b *Builder) ToSQL() (string, []interface{}, error) {
+	sql, args, err := b.toSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := checkParams(args); err != nil {
+		return "", nil, err
+	}
+	return sql, args, nil
+}
+
+// toSQL converts a builder to SQL and args which may include Params
+func (b *Builder) toSQL() (string, []interface{}, error) {
 	w := NewWriter()
 	if err := b.WriteTo(w); err != nil {
 		return "", nil, err
 	}
+	return w.toSQL(b.dialect)
+}
 
+// toSQL converts the placeholders written to those of the dialect, args of named placeholders
+// are passed as sql.NamedArg
+func (w *BytesWriter) toSQL(dialect string) (string, []interface{}, error) {
 	// in case of sql.NamedArg in args
 	for e := range w.args {
 		if namedArg, ok := w.args[e].(sql2.NamedArg); ok {
@@ -354,33 +429,94 @@ func (b *Builder) ToSQL() (string, []interface{}, error) {
 		}
 	}
 
-	var sql = w.writer.String()
-	var err error
+	// placeholders are kept for unknown dialects, but ?? is still unescaped
+	var placeholder = func(n int) string {
+		return "?"
+	}
 
-	switch b.dialect {
-	case ORACLE, MSSQL:
-		// This is for compatibility with different sql drivers
+	if d := LookupDialect(dialect); d != nil {
 		for e := range w.args {
-			w.args[e] = sql2.Named(fmt.Sprintf("p%d", e+1), w.args[e])
+			// This is for compatibility with different sql drivers
+			if _, name := d.Placeholder(e + 1); name != "" {
+				w.args[e] = sql2.Named(name, w.args[e])
+			}
 		}
 
-		var prefix string
-		if b.dialect == ORACLE {
-			prefix = ":p"
-		} else {
-			prefix = "@p"
+		placeholder = func(n int) string {
+			p, _ := d.Placeholder(n)
+			return p
+		}
+	}
+
+	sql, err := convertPlaceholders(w.writer.String(), dialectFamily(dialect), placeholder)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql, w.args, nil
+}
+
+// ToNamedSQL convert a builder to SQL with named placeholders and named args, which is for the
+// drivers preferring them. Args of named placeholders in Expr or sql.NamedArg keep their names
+// and are passed once even if they are referenced several times, others are named as p1, p2 ...
+func (b *Builder) ToNamedSQL() (string, []sql2.NamedArg, error) {
+	w := NewWriter()
+	if err := b.WriteTo(w); err != nil {
+		return "", nil, err
+	}
+	if err := checkParams(w.args); err != nil {
+		return "", nil, err
+	}
+
+	var prefix = "@"
+	if b.family() == ORACLE {
+		prefix = ":"
+	}
+
+	var taken = make(map[string]bool)
+	for i := range w.args {
+		if name := w.argName(i); name != "" {
+			taken[name] = true
+		}
+	}
+
+	var (
+		args    []sql2.NamedArg
+		indexes = make(map[string]int)
+		seq     int
+	)
+	sql, err := replacePlaceholders(w.writer.String(), b.family(), func(n int) (string, error) {
+		if n > len(w.args) {
+			return "", ErrNeedMoreArguments
 		}
 
-		if sql, err = ConvertPlaceholder(sql, prefix); err != nil {
-			return "", nil, err
+		name, value := w.argName(n-1), w.args[n-1]
+		if namedArg, ok := value.(sql2.NamedArg); ok {
+			value = namedArg.Value
 		}
-	case POSTGRES:
-		if sql, err = ConvertPlaceholder(sql, "$"); err != nil {
-			return "", nil, err
+
+		if name == "" {
+			for name == "" || taken[name] {
+				seq++
+				name = fmt.Sprintf("p%d", seq)
+			}
 		}
+
+		if idx, ok := indexes[name]; ok {
+			if !reflect.DeepEqual(args[idx].Value, value) {
+				return "", ErrInconsistentNamedArgument
+			}
+		} else {
+			indexes[name] = len(args)
+			args = append(args, sql2.Named(name, value))
+		}
+		return prefix + name, nil
+	})
+	if err != nil {
+		return "", nil, err
 	}
 
-	return sql, w.args, nil
+	return sql, args, nil
 }
 
 // ToBoundSQL
@@ -390,5 +526,9 @@ func (b *Builder) ToBoundSQL() (string, error) {
 		return "", err
 	}
 
-	return ConvertToBoundSQL(w.writer.String(), w.args)
+	format := formatLiteral
+	if d := LookupDialect(b.dialect); d != nil {
+		format = d.FormatLiteral
+	}
+	return convertToBoundSQL(w.writer.String(), b.family(), w.args, format)
 }
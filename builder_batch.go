@@ -0,0 +1,142 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+// maxInsertRows is the maximum number of rows which could be inserted by one statement
+var maxInsertRows = map[string]int{
+	MSSQL: 1000,
+}
+
+// BatchInsert creates an insert Builder with multiple rows, all of them should have the same columns
+func BatchInsert(rows ...Eq) *Builder {
+	builder := &Builder{cond: NewCond()}
+	return builder.Insert(rows)
+}
+
+// insertEqRows appends rows to a batch insert, the columns are taken from the first row
+func (b *Builder) insertEqRows(rows []Eq) {
+	if len(rows) == 0 {
+		return
+	}
+
+	if len(b.insertCols) == 0 {
+		b.insertCols = rows[0].sortedKeys()
+	}
+
+	for _, row := range rows {
+		vals := make([]interface{}, 0, len(b.insertCols))
+		for _, col := range b.insertCols {
+			v, ok := row[col]
+			if !ok {
+				break
+			}
+			vals = append(vals, v)
+		}
+		// an inconsistent row will be reported when writing
+		if len(row) != len(vals) {
+			vals = vals[:0]
+		}
+		b.Values(vals...)
+	}
+}
+
+// Values appends a row of values to an insert Builder, the values should be
+// in the same order as the columns given to Insert
+func (b *Builder) Values(vals ...interface{}) *Builder {
+	if len(b.batchRows) == 0 && len(b.insertVals) > 0 {
+		b.batchRows = append(b.batchRows, b.insertVals)
+		b.insertVals = nil
+	}
+	b.batchRows = append(b.batchRows, vals)
+	b.optype = insertType
+	return b
+}
+
+// maxArgs returns the maximum number of bind parameters which could be used in one statement,
+// which is given by the InListLimits of the dialect
+func (b *Builder) maxArgs() int {
+	if limiter, ok := LookupDialect(b.dialect).(InListLimiter); ok {
+		_, n := limiter.InListLimits()
+		return n
+	}
+	return 0
+}
+
+// rowArgs returns the number of bind parameters a row to insert will use
+func rowArgs(family string, row []interface{}) int {
+	var n int
+	for _, v := range row {
+		if e, ok := v.(expr); ok {
+			n += e.argsLen(family)
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// ToBatchSQL converts an insert Builder to one or more SQL statements and their args. The rows
+// are split into several statements when the number of bind parameters or rows would exceed
+// what the dialect accepts in one statement.
+func (b *Builder) ToBatchSQL() ([]string, [][]interface{}, error) {
+	if b.optype != insertType || len(b.batchRows) == 0 || b.from != "" {
+		sql, args, err := b.ToSQL()
+		if err != nil {
+			return nil, nil, err
+		}
+		return []string{sql}, [][]interface{}{args}, nil
+	}
+
+	var (
+		rows           = b.batchRows
+		limitArgs      = b.maxArgs()
+		limitRows      = maxInsertRows[b.family()]
+		sqls           []string
+		argss          [][]interface{}
+		start, numArgs int
+	)
+
+	// upsert updates and returning binds are repeated in every statement
+	if b.upsert != nil {
+		for _, update := range b.upsert.updates {
+			for _, v := range update {
+				numArgs += rowArgs(b.family(), []interface{}{v})
+			}
+		}
+	}
+	if b.returning != nil && b.family() == ORACLE {
+		numArgs += len(b.returning.cols)
+	}
+	var fixedArgs = numArgs
+
+	var flush = func(end int) error {
+		var sub = *b
+		sub.batchRows = rows[start:end]
+		sql, args, err := sub.ToSQL()
+		if err != nil {
+			return err
+		}
+		sqls = append(sqls, sql)
+		argss = append(argss, args)
+		start, numArgs = end, fixedArgs
+		return nil
+	}
+
+	for i, row := range rows {
+		n := rowArgs(b.family(), row)
+		if i > start && ((limitArgs > 0 && numArgs+n > limitArgs) || (limitRows > 0 && i-start >= limitRows)) {
+			if err := flush(i); err != nil {
+				return nil, nil, err
+			}
+		}
+		numArgs += n
+	}
+
+	if err := flush(len(rows)); err != nil {
+		return nil, nil, err
+	}
+
+	return sqls, argss, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_BatchInsert(t *testing.T) {
+	sql, args, err := BatchInsert(Eq{"a": 1, "b": 2}, Eq{"b": 4, "a": 3}).Into("table1").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (a,b) Values (?,?),(?,?)", sql)
+	assert.EqualValues(t, []interface{}{1, 2, 3, 4}, args)
+
+	sql, args, err = Insert("a", "b").Into("table1").Values(1, 2).Values(3, Expr("b+?", 1)).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (a,b) Values (?,?),(?,(b+?))", sql)
+	assert.EqualValues(t, []interface{}{1, 2, 3, 1}, args)
+
+	sql, args, err = Insert(Eq{"a": 1, "b": 2}).Into("table1").Values(3, 4).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (a,b) Values (?,?),(?,?)", sql)
+	assert.EqualValues(t, []interface{}{1, 2, 3, 4}, args)
+
+	sql, err = Oracle().Insert([]Eq{{"a": 1, "b": 2}, {"a": 3, "b": 4}}).Into("table1").ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT ALL INTO table1 (a,b) Values (1,2) INTO table1 (a,b) Values (3,4) SELECT 1 FROM DUAL", sql)
+
+	sql, err = MsSQL().Insert([]Eq{{"id": 1, "b": 2}, {"id": 3, "b": 4}}).Into("table1").
+		Upsert([]string{"id"}, Eq{"b": Incr(1)}).ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "MERGE INTO table1 USING (SELECT 2 AS b,1 AS id UNION ALL SELECT 4 AS b,3 AS id) AS src ON (table1.id=src.id) "+
+		"WHEN MATCHED THEN UPDATE SET b=(table1.b+1) WHEN NOT MATCHED THEN INSERT (b,id) Values (src.b,src.id);", sql)
+
+	_, _, err = BatchInsert(Eq{"a": 1, "b": 2}, Eq{"a": 3, "c": 4}).Into("table1").ToSQL()
+	assert.EqualValues(t, ErrInconsistentInsertRows, err)
+
+	// args are counted after named placeholders are resolved
+	assert.EqualValues(t, 3, rowArgs("", []interface{}{1, Expr("x+:n+:n", map[string]interface{}{"n": 1})}))
+
+	_, _, err = Insert("a", "b").Into("table1").Values(1, 2).Values(3).ToSQL()
+	assert.EqualValues(t, ErrInconsistentInsertRows, err)
+}
+
+func TestBuilder_ToBatchSQL(t *testing.T) {
+	var rows = make([]Eq, 0, 2500)
+	for i := 0; i < 2500; i++ {
+		rows = append(rows, Eq{"a": i, "b": i})
+	}
+
+	// 1000 rows at most in MSSQL
+	sqls, argss, err := MsSQL().Insert(rows).Into("table1").ToBatchSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, len(sqls))
+	assert.EqualValues(t, 2000, len(argss[0]))
+	assert.EqualValues(t, 1000, len(argss[2]))
+
+	// 2000 parameters at most in MSSQL, which leaves some of its 2100 to the driver
+	var wide = MsSQL().Insert("a", "b", "c").Into("table1")
+	for i := 0; i < 1500; i++ {
+		wide.Values(i, i, i)
+	}
+	sqls, argss, err = wide.ToBatchSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, len(sqls))
+	assert.EqualValues(t, 1998, len(argss[0]))
+	assert.EqualValues(t, 1998, len(argss[1]))
+	assert.EqualValues(t, 504, len(argss[2]))
+
+	// 999 parameters at most in SQLite
+	sqls, argss, err = SQLite().Insert(rows[:1000]).Into("table1").ToBatchSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, len(sqls))
+	assert.EqualValues(t, 998, len(argss[0]))
+	assert.EqualValues(t, 4, len(argss[2]))
+
+	sqls, argss, err = Postgres().Insert(rows).Into("table1").ToBatchSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, len(sqls))
+	assert.EqualValues(t, 5000, len(argss[0]))
+
+	sqls, argss, err = MySQL().Insert(Eq{"a": 1}).Into("table1").ToBatchSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"INSERT INTO table1 (a) Values (?)"}, sqls)
+	assert.EqualValues(t, [][]interface{}{{1}}, argss)
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CountQuery returns a query counting the rows of a select builder without ORDER BY, limit and
+// locking. Grouped, DISTINCT and UNION queries are counted through a derived table. The builder
+// itself is kept untouched so it could still be used to query a page.
+func (b *Builder) CountQuery() *Builder {
+	query := *b
+	query.orderBy = nil
+	query.limitation = nil
+	query.lock = nil
+
+	if query.optype == selectType {
+		switch {
+		case !query.needDerivedCount():
+			query.selects = []string{"COUNT(*)"}
+			query.selectArgs = nil
+			return &query
+		case query.isDistinct():
+			// columns of derived tables should have unique names
+			query.selects = aliasColumns(query.selects, b.family())
+		default:
+			// a grouped query is counted by its groups
+			query.selects = []string{"1 AS n"}
+			query.selectArgs = nil
+		}
+	}
+
+	// CTEs can't be nested in derived tables of some dialects
+	count := Dialect(b.dialect).Select("COUNT(*)").From(&query, "cnt")
+	count.ctes = query.ctes
+	count.quoted = query.quoted
+	query.ctes = nil
+	return count
+}
+
+// needDerivedCount tests if the rows of the query can't be counted by replacing the columns
+func (b *Builder) needDerivedCount() bool {
+	if len(b.groupBy) > 0 || (b.having != nil && b.having.IsValid()) {
+		return true
+	}
+
+	return b.isDistinct()
+}
+
+// isDistinct tests if the columns are selected with DISTINCT
+func (b *Builder) isDistinct() bool {
+	if len(b.selects) == 0 {
+		return false
+	}
+	s := strings.ToUpper(strings.TrimSpace(b.selects[0]))
+	// DISTINCT a or DISTINCT(a)
+	return strings.HasPrefix(s, "DISTINCT") && (len(s) == 8 || !isIdentifierByte(s[8]))
+}
+
+// aliasColumns names the columns without aliases as c1, c2 ..., so the columns of a derived table
+// are named and unique. Columns like a.* are kept
+func aliasColumns(selects []string, family string) []string {
+	var cols []string
+	for _, s := range selects {
+		cols = append(cols, splitColumns(s, family)...)
+	}
+
+	for i, col := range cols {
+		var prefix string
+		if i == 0 {
+			// DISTINCT a or DISTINCT(a)
+			prefix, col = col[:8]+" ", strings.TrimSpace(col[8:])
+		}
+		if !strings.HasSuffix(col, "*") && !hasAlias(col) {
+			col = fmt.Sprintf("%s AS c%d", col, i+1)
+		}
+		cols[i] = prefix + col
+	}
+	return cols
+}
+
+// splitColumns splits columns separated by commas, commas in parentheses, literals and
+// comments are kept
+func splitColumns(s, family string) []string {
+	var (
+		cols         []string
+		depth, start int
+	)
+	for i := 0; i < len(s); i++ {
+		if end, ok := skipLiteral(s, family, i); ok {
+			i = end
+			continue
+		}
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				cols = append(cols, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(cols, strings.TrimSpace(s[start:]))
+}
+
+// hasAlias tests if a column ends with an alias, like "a AS b" or "count(*) n"
+func hasAlias(col string) bool {
+	fields := strings.Fields(col)
+	if len(fields) < 2 {
+		return false
+	}
+	alias, prev := fields[len(fields)-1], fields[len(fields)-2]
+	if !isIdentifier(alias) && !isQuoted(alias) {
+		return false
+	}
+	if strings.EqualFold(prev, "AS") {
+		return true
+	}
+	// the end of CASE expressions
+	if strings.EqualFold(alias, "END") {
+		return false
+	}
+	c := prev[len(prev)-1]
+	return c == ')' || c == '"' || c == '`' || c == ']' || (isIdentifierByte(c) && !strings.EqualFold(prev, "DISTINCT"))
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_CountQuery(t *testing.T) {
+	page := Postgres().Select("u.id", "u.name").From("user u").
+		LeftJoin("orders o", Expr("o.uid=u.id AND o.status=?", 1)).
+		Where(Gt{"u.age": 18}).OrderBy(Desc("u.id")).Limit(10, 20).ForUpdate()
+
+	sql, args, err := page.CountQuery().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT COUNT(*) FROM user u LEFT JOIN orders o ON o.uid=u.id AND o.status=$1 WHERE u.age>$2", sql)
+	assert.EqualValues(t, []interface{}{1, 18}, args)
+
+	// the page query is kept
+	sql, args, err = page.ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id,u.name FROM user u LEFT JOIN orders o ON o.uid=u.id AND o.status=$1 "+
+		"WHERE u.age>$2 ORDER BY u.id DESC LIMIT 10 OFFSET 20 FOR UPDATE", sql)
+	assert.EqualValues(t, []interface{}{1, 18}, args)
+
+	sql, args, err = MsSQL().Select("a", "count(*)").From("t").Where(Eq{"b": 1}).GroupBy("a").
+		Having(Gt{"count(*)": 2}).OrderBy("a").Limit(5).CountQuery().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT COUNT(*) FROM (SELECT 1 AS n FROM t WHERE b=@p1 GROUP BY a HAVING count(*)>@p2) cnt", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	sql, args, err = Select("DISTINCT a").From("t").Where(Eq{"b": 1}).CountQuery().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT COUNT(*) FROM (SELECT DISTINCT a AS c1 FROM t WHERE b=?) cnt", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	// the columns of the derived table are named uniquely
+	sql, args, err = MySQL().Select("DISTINCT a.id", "b.id, CONCAT(b.x, ',', b.y) xy").From("a").
+		InnerJoin("b", "b.aid=a.id").Where(Eq{"b.c": 1}).CountQuery().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT COUNT(*) FROM (SELECT DISTINCT a.id AS c1,b.id AS c2,CONCAT(b.x, ',', b.y) xy "+
+		"FROM a INNER JOIN b ON b.aid=a.id WHERE b.c=?) cnt", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, args, err = MySQL().With("recent", Select("a").From("t").Where(Gt{"b": 1})).
+		Select("a").From("recent").Union("all", Select("a").From("t2").Where(Eq{"c": 2})).
+		OrderBy("a").Limit(10).CountQuery().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH recent AS (SELECT a FROM t WHERE b>?) SELECT COUNT(*) FROM "+
+		"((SELECT a FROM recent) UNION ALL (SELECT a FROM t2 WHERE c=?)) cnt", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	// a builder could be counted after its page has been written
+	page = Oracle().Select("DISTINCT a").From("t").Where(Eq{"b": 1}).OrderBy("a").Limit(10, 10)
+	_, _, err = page.ToSQL()
+	assert.NoError(t, err)
+	sql, _, err = page.CountQuery().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT COUNT(*) FROM (SELECT DISTINCT a AS c1 FROM t WHERE b=:p1) cnt", sql)
+
+	_, _, err = Insert(Eq{"a": 1}).Into("t").CountQuery().ToSQL()
+	assert.EqualError(t, err, ErrUnexpectedSubQuery.Error())
+}
@@ -19,9 +19,132 @@ func (b *Builder) deleteWriteTo(w Writer) error {
 		return ErrNoTableName
 	}
 
-	if _, err := fmt.Fprintf(w, "DELETE FROM %s WHERE ", b.from); err != nil {
+	if err := b.checkReturning(); err != nil {
 		return err
 	}
 
-	return b.cond.WriteTo(w)
+	if err := b.checkModifyLimit(); err != nil {
+		return err
+	}
+
+	if len(b.joins) > 0 {
+		return b.joinedDeleteWriteTo(w)
+	}
+
+	if err := b.checkWhere(); err != nil {
+		return err
+	}
+
+	if top, alias := b.orderedTop(); top != nil {
+		if _, err := fmt.Fprint(w, "DELETE ", quoteName(w, alias)); err != nil {
+			return err
+		}
+		if err := b.outputWriteTo(w, "DELETED"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, " FROM ("); err != nil {
+			return err
+		}
+		if err := top.WriteTo(w); err != nil {
+			return err
+		}
+		_, err := fmt.Fprint(w, ") ", quoteName(w, alias))
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, "DELETE"); err != nil {
+		return err
+	}
+
+	if err := b.topWriteTo(w); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, " FROM %s", quoteTable(w, b.from)); err != nil {
+		return err
+	}
+
+	if err := b.outputWriteTo(w, "DELETED"); err != nil {
+		return err
+	}
+
+	if cond := b.limitedCond(); cond.IsValid() {
+		if _, err := fmt.Fprint(w, " WHERE "); err != nil {
+			return err
+		}
+
+		if err := cond.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	if err := b.modifyLimitWriteTo(w); err != nil {
+		return err
+	}
+
+	return b.returningWriteTo(w)
+}
+
+// joinedDeleteWriteTo writes a DELETE statement which joins other tables
+func (b *Builder) joinedDeleteWriteTo(w Writer) error {
+	var cond = b.cond
+	switch b.family() {
+	case MYSQL, MSSQL:
+		if _, err := fmt.Fprint(w, "DELETE ", quoteName(w, tableRef(b.from))); err != nil {
+			return err
+		}
+		if err := b.outputWriteTo(w, "DELETED"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, " FROM ", quoteTable(w, b.from)); err != nil {
+			return err
+		}
+		for _, v := range b.joins {
+			if err := b.joinWriteTo(w, v); err != nil {
+				return err
+			}
+		}
+	case POSTGRES, SQLITE, ORACLE:
+		target := b.from
+		if b.family() == SQLITE {
+			target = aliasAs(target)
+		}
+		if _, err := fmt.Fprint(w, "DELETE FROM ", quoteTable(w, target)); err != nil {
+			return err
+		}
+
+		var err error
+		if cond, err = b.joinedWhere(w); err != nil {
+			return err
+		}
+
+		if b.family() == POSTGRES {
+			if _, err := fmt.Fprint(w, " USING "); err != nil {
+				return err
+			}
+			if err := b.joinTablesWriteTo(w); err != nil {
+				return err
+			}
+		}
+	case "":
+		return ErrDialectNotSetUp
+	default:
+		return ErrNotSupportDialectType
+	}
+
+	if err := b.checkWhere(); err != nil {
+		return err
+	}
+
+	if cond.IsValid() {
+		if _, err := fmt.Fprint(w, " WHERE "); err != nil {
+			return err
+		}
+
+		if err := cond.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	return b.returningWriteTo(w)
 }
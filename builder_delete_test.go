@@ -15,6 +15,17 @@ func TestBuilderDelete(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, "DELETE FROM table1 WHERE a=?", sql)
 	assert.EqualValues(t, []interface{}{1}, args)
+
+	// all rows are deleted only on purpose
+	_, _, err = Delete().From("table1").ToSQL()
+	assert.EqualValues(t, ErrNoWhereCondition, err)
+	_, _, err = MySQL().Delete().From("table1 t").LeftJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.EqualValues(t, ErrNoWhereCondition, err)
+
+	sql, args, err = Delete().From("table1").All().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1", sql)
+	assert.EqualValues(t, 0, len(args))
 }
 
 func TestDeleteNoTable(t *testing.T) {
@@ -22,3 +33,61 @@ func TestDeleteNoTable(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualValues(t, ErrNoTableName, err)
 }
+
+func TestBuilderDeleteJoin(t *testing.T) {
+	sql, args, err := MySQL().Delete(Eq{"u.d": 2}).From("table1 t").InnerJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE t FROM table1 t INNER JOIN table2 u ON u.id=t.id WHERE u.d=?", sql)
+	assert.EqualValues(t, []interface{}{2}, args)
+
+	sql, args, err = MsSQL().Delete(Eq{"u.d": 2}).From("table1").InnerJoin("table2 u", "u.id=table1.id").
+		Returning("id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE table1 OUTPUT DELETED.id FROM table1 INNER JOIN table2 u ON u.id=table1.id WHERE u.d=@p1", sql)
+	assert.EqualValues(t, 1, len(args))
+
+	sql, args, err = Postgres().Delete(Eq{"u.d": 2}).From("table1").InnerJoin("table2 u", Using{"id"}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 USING table2 u WHERE (table1.id=u.id) AND u.d=$1", sql)
+	assert.EqualValues(t, []interface{}{2}, args)
+
+	sql, args, err = Oracle().Delete(Eq{"u.d": 2}).From("table1 t").InnerJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 t WHERE EXISTS (SELECT 1 FROM table2 u WHERE (u.id=t.id) AND u.d=:p1)", sql)
+	assert.EqualValues(t, 1, len(args))
+
+	sql, args, err = SQLite().Delete(Eq{"u.d": 2}).From("table1 t").InnerJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 AS t WHERE EXISTS (SELECT 1 FROM table2 u WHERE (u.id=t.id) AND u.d=?)", sql)
+	assert.EqualValues(t, []interface{}{2}, args)
+
+	_, _, err = SQLite().Delete().From("table1 t").LeftJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
+
+func TestBuilderDeleteLimit(t *testing.T) {
+	sql, args, err := SQLite().Delete(Eq{"b": 2}).From("table1").OrderBy(Asc("id")).Limit(1000).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 WHERE rowid IN (SELECT rowid FROM table1 WHERE b=? ORDER BY id ASC LIMIT 1000)", sql)
+	assert.EqualValues(t, []interface{}{2}, args)
+
+	sql, args, err = Postgres().Delete(Eq{"b": 2}).From("table1").Limit(1000).Returning("id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 WHERE ctid IN (SELECT ctid FROM table1 WHERE b=$1 LIMIT 1000) RETURNING id", sql)
+	assert.EqualValues(t, []interface{}{2}, args)
+
+	sql, args, err = MsSQL().Delete(Eq{"b": 2}).From("table1").Limit(1000).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE TOP (1000) FROM table1 WHERE b=@p1", sql)
+	assert.EqualValues(t, 1, len(args))
+
+	sql, args, err = MsSQL().Delete(Eq{"b": 2}).From("table1").OrderBy("id").Limit(1000).Returning("id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE table1 OUTPUT DELETED.id FROM (SELECT TOP (1000) * FROM table1 WHERE b=@p1 ORDER BY id) table1", sql)
+	assert.EqualValues(t, 1, len(args))
+
+	sql, args, err = Oracle().Delete(Eq{"b": 2}).From("table1").Limit(1000).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 WHERE ROWID IN (SELECT rid FROM (SELECT ROWID rid FROM table1 WHERE b=:p1) at WHERE ROWNUM<=:p2)", sql)
+	assert.EqualValues(t, 2, len(args))
+}
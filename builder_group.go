@@ -0,0 +1,136 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+)
+
+// Grouping describes an item of GROUP BY clause
+type Grouping struct {
+	kind string
+	expr string
+	args []interface{}
+	sets [][]string
+	// raw expressions are never quoted
+	raw bool
+}
+
+// Rollup creates a ROLLUP grouping of the columns
+func Rollup(cols ...string) Grouping {
+	return Grouping{kind: "ROLLUP", sets: [][]string{cols}}
+}
+
+// Cube creates a CUBE grouping of the columns
+func Cube(cols ...string) Grouping {
+	return Grouping{kind: "CUBE", sets: [][]string{cols}}
+}
+
+// GroupingSets creates a GROUPING SETS grouping, every set is a list of columns
+func GroupingSets(sets ...[]string) Grouping {
+	return Grouping{kind: "GROUPING SETS", sets: sets}
+}
+
+// GroupBy appends items to GROUP BY clause, an item could be a column or expression
+// in string, an Expr with args, or a Grouping created by Rollup, Cube or GroupingSets
+func (b *Builder) GroupBy(groupBys ...interface{}) *Builder {
+	for _, groupBy := range groupBys {
+		switch t := groupBy.(type) {
+		case string:
+			if len(t) > 0 {
+				b.groupBy = append(b.groupBy, Grouping{expr: t})
+			}
+		case expr:
+			b.groupBy = append(b.groupBy, Grouping{expr: t.sql, args: t.args, raw: true})
+		case Grouping:
+			b.groupBy = append(b.groupBy, t)
+		}
+	}
+	return b
+}
+
+// Having sets having SQL, it could be a string or a Cond, and will be combined with AND
+// if it's called more than once
+func (b *Builder) Having(having interface{}) *Builder {
+	var cond Cond
+	switch t := having.(type) {
+	case string:
+		cond = Expr(t)
+	case Cond:
+		cond = t
+	default:
+		return b
+	}
+
+	if b.having != nil && b.having.IsValid() {
+		b.having = b.having.And(cond)
+	} else {
+		b.having = cond
+	}
+	return b
+}
+
+func (grouping Grouping) writeTo(w Writer, dialect string) error {
+	if len(grouping.kind) == 0 {
+		if grouping.raw || len(grouping.args) > 0 {
+			// named placeholders are resolved as Expr
+			return expr{grouping.expr, grouping.args}.WriteTo(w)
+		}
+		_, err := fmt.Fprint(w, quoteName(w, grouping.expr))
+		return err
+	}
+
+	if lacks(dialect, FeatureRollup) {
+		return ErrNotSupportDialectType
+	}
+	if lacks(dialect, FeatureGroupingSets) {
+		// only the WITH ROLLUP modifier of MySQL which applies to the whole GROUP BY clause
+		if grouping.kind != "ROLLUP" {
+			return ErrNotSupportDialectType
+		}
+		_, err := fmt.Fprint(w, quoteNames(w, grouping.sets[0]), " WITH ROLLUP")
+		return err
+	}
+
+	if grouping.kind != "GROUPING SETS" {
+		_, err := fmt.Fprintf(w, "%s (%s)", grouping.kind, quoteNames(w, grouping.sets[0]))
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, "GROUPING SETS ("); err != nil {
+		return err
+	}
+	for i, set := range grouping.sets {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "(%s)", quoteNames(w, set)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, ")")
+	return err
+}
+
+// groupByWriteTo writes items of GROUP BY clause without the keywords
+func (b *Builder) groupByWriteTo(w Writer) error {
+	for i, grouping := range b.groupBy {
+		if len(grouping.kind) > 0 && len(b.groupBy) > 1 && lacks(b.dialect, FeatureGroupingSets) {
+			return ErrNotSupportDialectType
+		}
+
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := grouping.writeTo(w, b.dialect); err != nil {
+			return err
+		}
+	}
+	return nil
+}
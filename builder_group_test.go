@@ -0,0 +1,59 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Having(t *testing.T) {
+	sql, args, err := Select("c", "count(*)").From("table1").Where(Eq{"a": 1}).GroupBy("c").
+		Having(Gt{"count(*)": 2}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT c,count(*) FROM table1 WHERE a=? GROUP BY c HAVING count(*)>?", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Select("c").From("table1").GroupBy("c").
+		Having("count(c)>1").Having(Expr("sum(d)<?", 10)).Having(Or(Eq{"c": "x"}, Eq{"c": "y"})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT c FROM table1 GROUP BY c HAVING (count(c)>1) AND (sum(d)<?) AND (c=? OR c=?)", sql)
+	assert.EqualValues(t, []interface{}{10, "x", "y"}, args)
+}
+
+func TestBuilder_GroupBy(t *testing.T) {
+	sql, args, err := Select("a", "b", "count(*)").From("table1").
+		GroupBy("a", Expr("SUBSTR(b, ?, ?)", 1, 2)).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a,b,count(*) FROM table1 GROUP BY a,SUBSTR(b, ?, ?)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, _, err = Postgres().Select("a", "b", "count(*)").From("table1").GroupBy("c").GroupBy(Rollup("a", "b")).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a,b,count(*) FROM table1 GROUP BY c,ROLLUP (a,b)", sql)
+
+	sql, _, err = MsSQL().Select("a", "b", "count(*)").From("table1").GroupBy(Cube("a", "b")).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a,b,count(*) FROM table1 GROUP BY CUBE (a,b)", sql)
+
+	sql, _, err = Oracle().Select("a", "b", "count(*)").From("table1").
+		GroupBy(GroupingSets([]string{"a", "b"}, []string{"a"}, []string{})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a,b,count(*) FROM table1 GROUP BY GROUPING SETS ((a,b),(a),())", sql)
+
+	sql, _, err = MySQL().Select("a", "b", "count(*)").From("table1").GroupBy(Rollup("a", "b")).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a,b,count(*) FROM table1 GROUP BY a,b WITH ROLLUP", sql)
+
+	_, _, err = MySQL().Select("a", "b", "count(*)").From("table1").GroupBy("c", Rollup("a", "b")).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = MySQL().Select("a", "b", "count(*)").From("table1").GroupBy(Cube("a", "b")).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = SQLite().Select("a", "b", "count(*)").From("table1").GroupBy(Rollup("a", "b")).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
@@ -5,7 +5,6 @@
 package builder
 
 import (
-	"bytes"
 	"fmt"
 )
 
@@ -16,19 +15,40 @@ func Insert(eq ...interface{}) *Builder {
 }
 
 func (b *Builder) insertSelectWriteTo(w Writer) error {
-	if _, err := fmt.Fprintf(w, "INSERT INTO %s ", b.into); err != nil {
+	if _, err := fmt.Fprintf(w, "INSERT INTO %s", quoteTable(w, b.into)); err != nil {
 		return err
 	}
 
 	if len(b.insertCols) > 0 {
-		fmt.Fprintf(w, "(")
-		for _, col := range b.insertCols {
-			fmt.Fprintf(w, col)
+		fmt.Fprint(w, " (")
+		for i, col := range b.insertCols {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprint(w, quoteName(w, col))
+		}
+		fmt.Fprint(w, ")")
+	}
+
+	if err := b.outputWriteTo(w, "INSERTED"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, " "); err != nil {
+		return err
+	}
+
+	if len(b.ctes) > 0 {
+		if err := b.withWriteTo(w); err != nil {
+			return err
 		}
-		fmt.Fprintf(w, ") ")
 	}
 
-	return b.selectWriteTo(w)
+	if err := b.selectWriteTo(w); err != nil {
+		return err
+	}
+
+	return b.returningWriteTo(w)
 }
 
 func (b *Builder) insertWriteTo(w Writer) error {
@@ -38,52 +58,139 @@ func (b *Builder) insertWriteTo(w Writer) error {
 	if len(b.insertCols) <= 0 && b.from == "" {
 		return ErrNoColumnToInsert
 	}
+	if err := b.checkReturning(); err != nil {
+		return err
+	}
 
 	if b.into != "" && b.from != "" {
+		// Oracle only supports RETURNING INTO on single row inserts
+		if b.upsert != nil || (b.returning != nil && b.family() == ORACLE) {
+			return ErrNotSupportType
+		}
 		return b.insertSelectWriteTo(w)
 	}
 
-	if _, err := fmt.Fprintf(w, "INSERT INTO %s (", b.into); err != nil {
+	// CTEs are left here only if they have to be put in front of SELECT
+	if len(b.ctes) > 0 {
+		return ErrNotSupportDialectType
+	}
+
+	if b.upsert != nil {
+		return b.upsertWriteTo(w)
+	}
+
+	if b.returning != nil && b.family() == ORACLE && len(b.batchRows) > 1 {
+		return ErrNotSupportType
+	}
+
+	if err := b.insertValuesWriteTo(w); err != nil {
 		return err
 	}
 
-	var args = make([]interface{}, 0)
-	var bs []byte
-	var valBuffer = bytes.NewBuffer(bs)
+	return b.returningWriteTo(w)
+}
 
-	for i, col := range b.insertCols {
-		value := b.insertVals[i]
-		fmt.Fprint(w, col)
-		if e, ok := value.(expr); ok {
-			fmt.Fprintf(valBuffer, "(%s)", e.sql)
-			args = append(args, e.args...)
-		} else {
-			fmt.Fprint(valBuffer, "?")
-			args = append(args, value)
+// insertRows returns all the rows to be inserted, a single row insert is
+// stored in insertVals while a batch insert is stored in insertRows
+func (b *Builder) insertRows() [][]interface{} {
+	if len(b.batchRows) > 0 {
+		return b.batchRows
+	}
+	return [][]interface{}{b.insertVals}
+}
+
+func (b *Builder) insertValuesWriteTo(w Writer) error {
+	rows := b.insertRows()
+	for _, row := range rows {
+		if len(row) != len(b.insertCols) {
+			return ErrInconsistentInsertRows
 		}
+	}
 
-		if i != len(b.insertCols)-1 {
+	if len(rows) > 1 && b.family() == ORACLE {
+		return b.insertAllWriteTo(w, rows)
+	}
+
+	into := b.into
+	// Postgres and SQLite take an alias of the target with AS only
+	if b.family() == POSTGRES || b.family() == SQLITE {
+		into = aliasAs(into)
+	}
+	if _, err := fmt.Fprintf(w, "INSERT INTO %s (%s)", quoteTable(w, into), quoteNames(w, b.insertCols)); err != nil {
+		return err
+	}
+
+	if err := b.outputWriteTo(w, "INSERTED"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, " Values "); err != nil {
+		return err
+	}
+
+	for i, row := range rows {
+		if i > 0 {
 			if _, err := fmt.Fprint(w, ","); err != nil {
 				return err
 			}
-			if _, err := fmt.Fprint(valBuffer, ","); err != nil {
-				return err
-			}
+		}
+		if err := writeInsertRow(w, row); err != nil {
+			return err
 		}
 	}
 
-	if _, err := fmt.Fprint(w, ") Values ("); err != nil {
+	return nil
+}
+
+// insertAllWriteTo writes a batch insert in Oracle which doesn't support multiple rows in VALUES
+func (b *Builder) insertAllWriteTo(w Writer, rows [][]interface{}) error {
+	if _, err := fmt.Fprint(w, "INSERT ALL"); err != nil {
 		return err
 	}
 
-	if _, err := w.Write(valBuffer.Bytes()); err != nil {
-		return err
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, " INTO %s (%s) Values ", quoteTable(w, b.into), quoteNames(w, b.insertCols)); err != nil {
+			return err
+		}
+		if err := writeInsertRow(w, row); err != nil {
+			return err
+		}
 	}
-	if _, err := fmt.Fprint(w, ")"); err != nil {
+
+	_, err := fmt.Fprint(w, " SELECT 1 FROM DUAL")
+	return err
+}
+
+func writeInsertRow(w Writer, row []interface{}) error {
+	if _, err := fmt.Fprint(w, "("); err != nil {
 		return err
 	}
 
-	w.Append(args...)
+	for i, value := range row {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
 
-	return nil
+		if e, ok := value.(expr); ok {
+			if _, err := fmt.Fprint(w, "("); err != nil {
+				return err
+			}
+			if err := e.WriteTo(w); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprint(w, ")"); err != nil {
+				return err
+			}
+		} else {
+			if _, err := fmt.Fprint(w, "?"); err != nil {
+				return err
+			}
+			w.Append(value)
+		}
+	}
+
+	_, err := fmt.Fprint(w, ")")
+	return err
 }
@@ -0,0 +1,263 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Using defines the USING (cols) condition of a join
+type Using []string
+
+// As sets the alias of a sub-query which is used as a joined or derived table
+func (b *Builder) As(alias string) *Builder {
+	b.alias = alias
+	return b
+}
+
+// NaturalJoin sets natural join SQL
+func (b *Builder) NaturalJoin(joinTable interface{}) *Builder {
+	return b.Join("NATURAL", joinTable, nil)
+}
+
+// LateralJoin sets a join with a sub-query which could reference the preceding tables,
+// it will be written as CROSS APPLY or OUTER APPLY in MSSQL
+func (b *Builder) LateralJoin(joinType string, joinTable *Builder, joinCond interface{}) *Builder {
+	b.Join(joinType, joinTable, joinCond)
+	if len(b.joins) > 0 {
+		b.joins[len(b.joins)-1].lateral = true
+	}
+	return b
+}
+
+func (b *Builder) joinWriteTo(w Writer, j join) error {
+	joinType := strings.ToUpper(strings.TrimSpace(j.joinType))
+
+	if b.family() == MSSQL && (len(j.using) > 0 || strings.HasPrefix(joinType, "NATURAL")) {
+		return ErrNotSupportDialectType
+	}
+	if j.lateral && lacks(b.dialect, FeatureLateralJoin) {
+		return ErrNotSupportDialectType
+	}
+
+	var apply bool
+	if j.lateral && b.family() == MSSQL {
+		// APPLY has no join condition, the sub-query should be correlated instead
+		if j.joinCond.IsValid() {
+			return ErrNotSupportDialectType
+		}
+
+		switch joinType {
+		case "", "INNER", "CROSS":
+			if _, err := fmt.Fprint(w, " CROSS APPLY "); err != nil {
+				return err
+			}
+		case "LEFT", "LEFT OUTER":
+			if _, err := fmt.Fprint(w, " OUTER APPLY "); err != nil {
+				return err
+			}
+		default:
+			return ErrNotSupportDialectType
+		}
+		apply = true
+	} else if j.lateral {
+		if _, err := fmt.Fprintf(w, " %s JOIN LATERAL ", j.joinType); err != nil {
+			return err
+		}
+	} else {
+		if _, err := fmt.Fprintf(w, " %s JOIN ", j.joinType); err != nil {
+			return err
+		}
+	}
+
+	if err := b.joinTableWriteTo(w, j); err != nil {
+		return err
+	}
+
+	switch {
+	case len(j.using) > 0:
+		_, err := fmt.Fprintf(w, " USING (%s)", quoteNames(w, j.using))
+		return err
+	case j.joinCond.IsValid():
+		if _, err := fmt.Fprint(w, " ON "); err != nil {
+			return err
+		}
+		return j.joinCond.WriteTo(w)
+	case j.lateral && !apply && joinType != "CROSS":
+		// a lateral join needs a condition even it's correlated in the sub-query
+		_, err := fmt.Fprint(w, " ON 1=1")
+		return err
+	}
+
+	return nil
+}
+
+// joinTableWriteTo writes the joined table or sub-query with its alias
+func (b *Builder) joinTableWriteTo(w Writer, j join) error {
+	switch t := j.joinTable.(type) {
+	case string:
+		if _, err := fmt.Fprint(w, quoteTable(w, t)); err != nil {
+			return err
+		}
+	case *Builder:
+		if len(t.alias) == 0 {
+			return ErrUnnamedDerivedTable
+		}
+		if t.dialect != "" && b.family() != t.family() {
+			return ErrInconsistentDialect
+		}
+
+		// dialect of sub-query will inherit from the main one (if not set up)
+		if b.dialect != "" && t.dialect == "" {
+			t.dialect = b.dialect
+		}
+
+		switch t.optype {
+		case selectType, unionType:
+		default:
+			return ErrUnexpectedSubQuery
+		}
+
+		if _, err := fmt.Fprint(w, "("); err != nil {
+			return err
+		}
+		if err := t.WriteTo(w); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, ") ", quoteName(w, t.alias)); err != nil {
+			return err
+		}
+	default:
+		return ErrNotSupportType
+	}
+
+	return nil
+}
+
+// tableRef returns the name to reference a table in conditions, i.e. its alias if there is one
+func tableRef(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return table
+	}
+	return fields[len(fields)-1]
+}
+
+// aliasAs writes the alias of a table with AS, e.g. "table1 AS t", which SQLite requires for the
+// target of UPDATE and DELETE
+func aliasAs(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) != 2 {
+		return table
+	}
+	return fields[0] + " AS " + fields[1]
+}
+
+// innerJoinConds converts inner joins to conditions on a list of tables, which is how
+// the dialects without joined UPDATE or DELETE express them
+func (b *Builder) innerJoinConds(w Writer) ([]Cond, error) {
+	var conds []Cond
+	for _, j := range b.joins {
+		switch strings.ToUpper(strings.TrimSpace(j.joinType)) {
+		case "", "INNER", "CROSS":
+		default:
+			return nil, ErrNotSupportDialectType
+		}
+		if j.lateral {
+			return nil, ErrNotSupportDialectType
+		}
+
+		if len(j.using) > 0 {
+			var joined string
+			switch t := j.joinTable.(type) {
+			case string:
+				joined = tableRef(t)
+			case *Builder:
+				joined = t.alias
+			}
+
+			for _, col := range j.using {
+				conds = append(conds, Expr(fmt.Sprintf("%s.%s=%s.%s", quoteName(w, tableRef(b.from)), quoteName(w, col),
+					quoteName(w, joined), quoteName(w, col))))
+			}
+		} else if j.joinCond.IsValid() {
+			conds = append(conds, j.joinCond)
+		}
+	}
+	return conds, nil
+}
+
+// joinTablesWriteTo writes the joined tables as a list separated by comma
+func (b *Builder) joinTablesWriteTo(w Writer) error {
+	for i, j := range b.joins {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ", "); err != nil {
+				return err
+			}
+		}
+		if err := b.joinTableWriteTo(w, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinedWhere returns the WHERE condition of a joined UPDATE or DELETE in Postgres, SQLite and
+// Oracle. Postgres lists the joined tables in FROM or USING clause and their conditions are moved
+// into WHERE, the others check all the conditions in a correlated EXISTS sub-query, so values
+// to update can't reference the joined tables
+func (b *Builder) joinedWhere(w Writer) (Cond, error) {
+	conds, err := b.innerJoinConds(w)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.family() == POSTGRES {
+		return And(append(conds, b.cond)...), nil
+	}
+	return condJoinExists{b, append(conds, b.cond)}, nil
+}
+
+type condJoinExists struct {
+	builder *Builder
+	conds   []Cond
+}
+
+var _ Cond = condJoinExists{}
+
+func (condJoinExists condJoinExists) WriteTo(w Writer) error {
+	if _, err := fmt.Fprint(w, "EXISTS (SELECT 1 FROM "); err != nil {
+		return err
+	}
+	if err := condJoinExists.builder.joinTablesWriteTo(w); err != nil {
+		return err
+	}
+
+	cond := And(condJoinExists.conds...)
+	if cond.IsValid() {
+		if _, err := fmt.Fprint(w, " WHERE "); err != nil {
+			return err
+		}
+		if err := cond.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprint(w, ")")
+	return err
+}
+
+func (condJoinExists condJoinExists) And(conds ...Cond) Cond {
+	return And(condJoinExists, And(conds...))
+}
+
+func (condJoinExists condJoinExists) Or(conds ...Cond) Cond {
+	return Or(condJoinExists, Or(conds...))
+}
+
+func (condJoinExists condJoinExists) IsValid() bool {
+	return condJoinExists.builder != nil && len(condJoinExists.builder.joins) > 0
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_JoinSubQuery(t *testing.T) {
+	sql, args, err := Postgres().Select("u.id").From("users u").
+		InnerJoin(Select("uid", "max(ts) ts").From("logs").Where(Gt{"ts": 5}).GroupBy("uid").As("l"), "l.uid=u.id").
+		Where(Eq{"u.a": 1}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id FROM users u INNER JOIN (SELECT uid,max(ts) ts FROM logs WHERE ts>$1 GROUP BY uid) l "+
+		"ON l.uid=u.id WHERE u.a=$2", sql)
+	assert.EqualValues(t, []interface{}{5, 1}, args)
+
+	// dialect of sub-query will inherit from the main one
+	sql, args, err = MySQL().Select("u.id").From("users u").
+		LeftJoin(Select("uid").From("logs").Limit(3).As("l"), Eq{"l.uid": Expr("u.id")}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id FROM users u LEFT JOIN (SELECT uid FROM logs LIMIT 3) l ON l.uid=(u.id)", sql)
+	assert.EqualValues(t, 0, len(args))
+
+	_, _, err = Select("u.id").From("users u").InnerJoin(Select("a").From("b"), "b.a=u.id").ToSQL()
+	assert.EqualValues(t, ErrUnnamedDerivedTable, err)
+
+	_, _, err = MySQL().Select("u.id").From("users u").InnerJoin(Oracle().Select("a").From("b").As("b"), "b.a=u.id").ToSQL()
+	assert.EqualValues(t, ErrInconsistentDialect, err)
+}
+
+func TestBuilder_JoinUsing(t *testing.T) {
+	sql, _, err := Postgres().Select("u.id").From("users u").Join("LEFT", "t2", Using{"id", "b"}).
+		NaturalJoin("t3").CrossJoin("t4", nil).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id FROM users u LEFT JOIN t2 USING (id,b) NATURAL JOIN t3 CROSS JOIN t4", sql)
+
+	_, _, err = MsSQL().Select("u.id").From("users u").InnerJoin("t2", Using{"id"}).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = MsSQL().Select("u.id").From("users u").NaturalJoin("t2").ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
+
+func TestBuilder_LateralJoin(t *testing.T) {
+	latest := func() *Builder {
+		return Select("o.id").From("orders o").Where(Expr("o.uid=u.id")).OrderBy(Desc("o.ts")).Limit(1).As("lo")
+	}
+
+	sql, _, err := Postgres().Select("u.id").From("users u").LateralJoin("LEFT", latest(), nil).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id FROM users u LEFT JOIN LATERAL (SELECT o.id FROM orders o WHERE o.uid=u.id "+
+		"ORDER BY o.ts DESC LIMIT 1) lo ON 1=1", sql)
+
+	sql, args, err := MySQL().Select("u.id").From("users u").LateralJoin("INNER", latest(), Eq{"lo.id": 2}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id FROM users u INNER JOIN LATERAL (SELECT o.id FROM orders o WHERE o.uid=u.id "+
+		"ORDER BY o.ts DESC LIMIT 1) lo ON lo.id=?", sql)
+	assert.EqualValues(t, []interface{}{2}, args)
+
+	sql, _, err = Postgres().Select("u.id").From("users u").LateralJoin("CROSS", latest(), nil).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id FROM users u CROSS JOIN LATERAL (SELECT o.id FROM orders o WHERE o.uid=u.id "+
+		"ORDER BY o.ts DESC LIMIT 1) lo", sql)
+
+	sql, _, err = MsSQL().Select("u.id").From("users u").
+		LateralJoin("LEFT", Select("o.id").From("orders o").Where(Expr("o.uid=u.id")).As("lo"), nil).
+		LateralJoin("CROSS", Select("p.id").From("payments p").Where(Expr("p.uid=u.id")).As("lp"), nil).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT u.id FROM users u OUTER APPLY (SELECT o.id FROM orders o WHERE o.uid=u.id) lo "+
+		"CROSS APPLY (SELECT p.id FROM payments p WHERE p.uid=u.id) lp", sql)
+
+	_, _, err = MsSQL().Select("u.id").From("users u").LateralJoin("LEFT", latest(), "lo.id=u.id").ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = SQLite().Select("u.id").From("users u").LateralJoin("LEFT", latest(), nil).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
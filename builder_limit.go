@@ -19,25 +19,44 @@ func (b *Builder) limitWriteTo(w Writer) error {
 		if limit.offset < 0 || limit.limitN <= 0 {
 			return ErrInvalidLimitation
 		}
-		// erase limit condition
+		rewrite := b.rewriteLimit()
+		// erase limit condition, the builder is restored after written so it could be written again
+		selects, selectArgs := b.selects, b.selectArgs
+		defer func() {
+			b.limitation, b.selects, b.selectArgs = limit, selects, selectArgs
+		}()
 		b.limitation = nil
 		ow := w.(*BytesWriter)
 
-		switch strings.ToLower(strings.TrimSpace(b.dialect)) {
+		if !rewrite {
+			clause := b.nativeLimit(limit)
+			if clause == "" {
+				return ErrNotSupportType
+			}
+			_, err := fmt.Fprint(ow, clause)
+			return err
+		}
+
+		switch b.family() {
 		case ORACLE:
+			if b.lock != nil && b.optype != unionType {
+				return b.lockedLimitWriteTo(ow, limit)
+			}
+
 			if len(b.selects) == 0 {
 				b.selects = append(b.selects, "*")
 			}
 
 			var final *Builder
 			selects := b.selects
-			b.selects = append(selects, "ROWNUM RN")
 
 			var wb *Builder
-			if b.optype == unionType {
+			if b.optype == unionType || len(b.orderBy) > 0 {
+				// ROWNUM is assigned before ORDER BY, so the ordered rows are numbered outside
 				wb = Dialect(b.dialect).Select("at.*", "ROWNUM RN").
 					From(b, "at")
 			} else {
+				b.selects = append(selects, "ROWNUM RN")
 				wb = b
 			}
 
@@ -46,55 +65,214 @@ func (b *Builder) limitWriteTo(w Writer) error {
 					Where(Lte{"at.RN": limit.limitN})
 			} else {
 				sub := Dialect(b.dialect).Select("*").
-					From(b, "at").Where(Lte{"at.RN": limit.offset + limit.limitN})
+					From(wb, "at").Where(Lte{"at.RN": limit.offset + limit.limitN})
 
 				final = Dialect(b.dialect).Select(selects...).From(sub, "att").
 					Where(Gt{"att.RN": limit.offset})
 			}
 
 			return final.WriteTo(ow)
-		case SQLITE, MYSQL, POSTGRES:
-			// if type UNION, we need to write previous content back to current writer
-			if b.optype == unionType {
-				if err := b.WriteTo(ow); err != nil {
-					return err
-				}
-			}
-
-			if limit.offset == 0 {
-				fmt.Fprint(ow, " LIMIT ", limit.limitN)
-			} else {
-				fmt.Fprintf(ow, " LIMIT %v OFFSET %v", limit.limitN, limit.offset)
-			}
 		case MSSQL:
 			if len(b.selects) == 0 {
 				b.selects = append(b.selects, "*")
 			}
 
+			rowNumber, rowNumberArgs, err := b.rowNumberSelect()
+			if err != nil {
+				return err
+			}
+
 			var final *Builder
 			selects := b.selects
 			b.selects = append(append([]string{fmt.Sprintf("TOP %d %v", limit.limitN+limit.offset, b.selects[0])},
-				b.selects[1:]...), "ROW_NUMBER() OVER (ORDER BY (SELECT 1)) AS RN")
+				b.selects[1:]...), rowNumber)
 
 			var wb *Builder
 			if b.optype == unionType {
-				wb = Dialect(b.dialect).Select("*", "ROW_NUMBER() OVER (ORDER BY (SELECT 1)) AS RN").
+				wb = Dialect(b.dialect).Select("*", rowNumber).
 					From(b, "at")
+				wb.selectArgs = rowNumberArgs
+
+				// ORDER BY is not allowed in derived tables without TOP, rows
+				// have been numbered in its order instead
+				orderBy := b.orderBy
+				b.orderBy = nil
+				defer func() {
+					b.orderBy = orderBy
+				}()
 			} else {
 				wb = b
+				b.selectArgs = append(b.selectArgs, rowNumberArgs...)
 			}
 
-			if limit.offset == 0 {
-				final = Dialect(b.dialect).Select(selects...).From(wb, "at")
-			} else {
-				final = Dialect(b.dialect).Select(selects...).From(wb, "at").Where(Gt{"at.RN": limit.offset})
+			// the derived table keeps no order, TOP allows ORDER BY if it's a sub-query as well
+			final = Dialect(b.dialect).Select(append([]string{fmt.Sprintf("TOP %d %v", limit.limitN, selects[0])},
+				selects[1:]...)...).From(wb, "at").OrderBy("at.RN")
+			if b.optype == unionType {
+				// there is no TOP for the combined result, limit it by row number
+				final.Where(Lte{"at.RN": limit.offset + limit.limitN})
+			}
+			if limit.offset > 0 {
+				final.Where(Gt{"at.RN": limit.offset})
 			}
 
 			return final.WriteTo(ow)
-		default:
-			return ErrNotSupportType
 		}
 	}
 
 	return nil
 }
+
+// nativeLimit returns the limit clause of the dialect, it's empty if the limit should be emulated
+func (b *Builder) nativeLimit(limit *limit) string {
+	d := LookupDialect(b.dialect)
+	if d == nil || limit == nil {
+		return ""
+	}
+	return d.LimitClause(limit.limitN, limit.offset, len(b.orderBy) > 0)
+}
+
+// rewriteLimit tests if the limit should be emulated by sub-queries. Oracle can't lock rows
+// with FETCH, so it's emulated in that case even if OFFSET ... FETCH is supported
+func (b *Builder) rewriteLimit() bool {
+	switch b.family() {
+	case MSSQL:
+		return b.nativeLimit(b.limitation) == ""
+	case ORACLE:
+		return b.nativeLimit(b.limitation) == "" || (b.lock != nil && b.optype != unionType)
+	}
+	return false
+}
+
+// rowNumberSelect returns the ROW_NUMBER() column used to paginate in MSSQL and its args,
+// rows are numbered in the order of ORDER BY clause if there is one
+func (b *Builder) rowNumberSelect() (string, []interface{}, error) {
+	if len(b.orderBy) == 0 {
+		return "ROW_NUMBER() OVER (ORDER BY (SELECT 1)) AS RN", nil, nil
+	}
+
+	w := NewWriter()
+	if err := b.orderByWriteTo(w); err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("ROW_NUMBER() OVER (ORDER BY %s) AS RN", w.writer.String()), w.args, nil
+}
+
+// limitedRowids returns a query of the ROWID of the rows in the range of limit in Oracle
+func (b *Builder) limitedRowids(limit *limit) *Builder {
+	if b.nativeLimit(limit) != "" {
+		rowids := Dialect(b.dialect).Select("ROWID").From(b.from).Where(b.cond)
+		rowids.orderBy = b.orderBy
+		l := *limit
+		rowids.limitation = &l
+		return rowids
+	}
+
+	inner := Dialect(b.dialect).Select("ROWID rid").From(b.from).Where(b.cond)
+	inner.orderBy = b.orderBy
+
+	if limit.offset == 0 {
+		return Dialect(b.dialect).Select("rid").From(inner, "at").
+			Where(Lte{"ROWNUM": limit.limitN})
+	}
+
+	sub := Dialect(b.dialect).Select("rid", "ROWNUM RN").From(inner, "at").
+		Where(Lte{"ROWNUM": limit.offset + limit.limitN})
+	return Dialect(b.dialect).Select("rid").From(sub, "att").
+		Where(Gt{"att.RN": limit.offset})
+}
+
+// checkModifyLimit checks the limit and order of UPDATE or DELETE, which only accept
+// a limit without offset on a single table
+func (b *Builder) checkModifyLimit() error {
+	if b.limitation == nil && len(b.orderBy) == 0 {
+		return nil
+	}
+	if len(b.joins) > 0 {
+		return ErrNotSupportDialectType
+	}
+	if b.limitation == nil {
+		return nil
+	}
+
+	if b.limitation.offset != 0 || b.limitation.limitN <= 0 {
+		return ErrInvalidLimitation
+	}
+	if b.dialect == "" {
+		return ErrDialectNotSetUp
+	}
+	return nil
+}
+
+// limitedCond returns the condition of UPDATE or DELETE with limit in Postgres, SQLite and Oracle,
+// which don't support limit on them (SQLite only when it's compiled with the option). The rows to
+// modify are located by their ctid, rowid or ROWID
+func (b *Builder) limitedCond() Cond {
+	if b.limitation == nil {
+		return b.cond
+	}
+
+	switch b.family() {
+	case POSTGRES, SQLITE:
+		id := "ctid"
+		if b.family() == SQLITE {
+			id = "rowid"
+		}
+		sub := Dialect(b.dialect).Select(id).From(b.from).Where(b.cond)
+		sub.orderBy = b.orderBy
+		sub.limitation = &limit{limitN: b.limitation.limitN}
+		return In(id, sub)
+	case ORACLE:
+		return In("ROWID", b.limitedRowids(b.limitation))
+	}
+	return b.cond
+}
+
+// topWriteTo writes TOP of UPDATE or DELETE without order in MSSQL
+func (b *Builder) topWriteTo(w Writer) error {
+	if b.limitation == nil || b.family() != MSSQL || len(b.orderBy) > 0 {
+		return nil
+	}
+	_, err := fmt.Fprintf(w, " TOP (%d)", b.limitation.limitN)
+	return err
+}
+
+// orderedTop returns the rows to modify by UPDATE or DELETE with limit and order in MSSQL,
+// which is used as a derived table named as the table since TOP of them ignores ORDER BY
+func (b *Builder) orderedTop() (*Builder, string) {
+	if b.limitation == nil || b.family() != MSSQL || len(b.orderBy) == 0 {
+		return nil, ""
+	}
+
+	sub := Dialect(b.dialect).Select(fmt.Sprintf("TOP (%d) *", b.limitation.limitN)).From(b.from).Where(b.cond)
+	sub.orderBy = b.orderBy
+
+	alias := tableRef(b.from)
+	if idx := strings.LastIndex(alias, "."); idx > -1 {
+		alias = alias[idx+1:]
+	}
+	return sub, alias
+}
+
+// modifyLimitWriteTo writes ORDER BY and LIMIT of UPDATE or DELETE in MySQL
+func (b *Builder) modifyLimitWriteTo(w Writer) error {
+	if b.family() != MYSQL {
+		return nil
+	}
+
+	if len(b.orderBy) > 0 {
+		if _, err := fmt.Fprint(w, " ORDER BY "); err != nil {
+			return err
+		}
+		if err := b.orderByWriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	if b.limitation != nil {
+		if _, err := fmt.Fprint(w, " LIMIT ", b.limitation.limitN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
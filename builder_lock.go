@@ -0,0 +1,130 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+type lock struct {
+	mode string
+	of   []string
+	wait string
+}
+
+// ForUpdate locks the selected rows for update, of limits the lock to some tables (or columns in Oracle)
+func (b *Builder) ForUpdate(of ...string) *Builder {
+	b.lock = &lock{mode: "UPDATE", of: of}
+	return b
+}
+
+// ForShare locks the selected rows in share mode, of limits the lock to some tables
+func (b *Builder) ForShare(of ...string) *Builder {
+	b.lock = &lock{mode: "SHARE", of: of}
+	return b
+}
+
+// NoWait reports an error instead of waiting when the rows to lock are locked by others
+func (b *Builder) NoWait() *Builder {
+	if b.lock == nil {
+		b.lock = &lock{mode: "UPDATE"}
+	}
+	b.lock.wait = "NOWAIT"
+	return b
+}
+
+// SkipLocked skips the rows which are locked by others
+func (b *Builder) SkipLocked() *Builder {
+	if b.lock == nil {
+		b.lock = &lock{mode: "UPDATE"}
+	}
+	b.lock.wait = "SKIP LOCKED"
+	return b
+}
+
+func (b *Builder) checkLock() error {
+	if b.lock == nil {
+		return nil
+	}
+
+	if lacks(b.dialect, FeatureRowLocking) {
+		return ErrNotSupportDialectType
+	}
+
+	switch b.family() {
+	case ORACLE:
+		if b.lock.mode == "SHARE" {
+			return ErrNotSupportDialectType
+		}
+	case MSSQL:
+		// table hints could only be applied to a table
+		if b.subQuery != nil || len(b.lock.of) > 0 {
+			return ErrNotSupportDialectType
+		}
+	}
+	return nil
+}
+
+// lockHintWriteTo writes the locking table hints of MSSQL after the table name
+func (b *Builder) lockHintWriteTo(w Writer) error {
+	if b.lock == nil || b.family() != MSSQL {
+		return nil
+	}
+
+	var hints = []string{"UPDLOCK", "ROWLOCK"}
+	if b.lock.mode == "SHARE" {
+		hints = []string{"HOLDLOCK", "ROWLOCK"}
+	}
+
+	switch b.lock.wait {
+	case "NOWAIT":
+		hints = append(hints, "NOWAIT")
+	case "SKIP LOCKED":
+		hints = append(hints, "READPAST")
+	}
+
+	_, err := fmt.Fprintf(w, " WITH (%s)", strings.Join(hints, ", "))
+	return err
+}
+
+// lockWriteTo writes the trailing locking clause
+func (b *Builder) lockWriteTo(w Writer) error {
+	if b.lock == nil || b.family() == MSSQL {
+		return nil
+	}
+
+	if _, err := fmt.Fprint(w, " FOR ", b.lock.mode); err != nil {
+		return err
+	}
+
+	if len(b.lock.of) > 0 {
+		if _, err := fmt.Fprint(w, " OF ", quoteNames(w, b.lock.of)); err != nil {
+			return err
+		}
+	}
+
+	if len(b.lock.wait) > 0 {
+		if _, err := fmt.Fprint(w, " ", b.lock.wait); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockedLimitWriteTo writes a locking query with limit in Oracle. Rows can't be locked
+// through the ROWNUM sub-queries, so they are located by their ROWID at first.
+func (b *Builder) lockedLimitWriteTo(w Writer, limit *limit) error {
+	if b.subQuery != nil || len(b.joins) > 0 || len(b.groupBy) > 0 {
+		return ErrNotSupportDialectType
+	}
+
+	final := Dialect(b.dialect).Select(b.selects...).From(b.from).Where(In("ROWID", b.limitedRowids(limit)))
+	final.orderBy = b.orderBy
+	final.lock = b.lock
+
+	return final.WriteTo(w)
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Lock(t *testing.T) {
+	sql, args, err := Postgres().Select("a").From("table1").Where(Eq{"a": 1}).ForUpdate("table1").SkipLocked().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 WHERE a=$1 FOR UPDATE OF table1 SKIP LOCKED", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, _, err = MySQL().Select("a").From("table1").Limit(5).ForShare().NoWait().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 LIMIT 5 FOR SHARE NOWAIT", sql)
+
+	sql, _, err = Oracle().Select("a").From("table1").ForUpdate("a").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 FOR UPDATE OF a", sql)
+
+	sql, args, err = MsSQL().Select("a").From("table1").Where(Eq{"a": 1}).Limit(5, 10).ForUpdate().SkipLocked().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT TOP 5 a FROM (SELECT TOP 15 a,ROW_NUMBER() OVER (ORDER BY (SELECT 1)) AS RN "+
+		"FROM table1 WITH (UPDLOCK, ROWLOCK, READPAST) WHERE a=@p1) at WHERE at.RN>@p2 ORDER BY at.RN", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	sql, _, err = MsSQL().Select("a").From("table1").ForShare().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 WITH (HOLDLOCK, ROWLOCK)", sql)
+
+	_, _, err = SQLite().Select("a").From("table1").ForUpdate().ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = Oracle().Select("a").From("table1").ForShare().ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = MsSQL().Select("a").From(Select("a").From("table1"), "t").ForUpdate().ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
+
+func TestBuilder_LockWithOracleLimit(t *testing.T) {
+	sql, args, err := Oracle().Select("a").From("table1").Where(Eq{"a": 1}).OrderBy("a").
+		Limit(5).ForUpdate().NoWait().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 WHERE ROWID IN (SELECT rid FROM (SELECT ROWID rid FROM table1 "+
+		"WHERE a=:p1 ORDER BY a) at WHERE ROWNUM<=:p2) ORDER BY a FOR UPDATE NOWAIT", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	sql, args, err = Oracle().Select("a").From("table1").Where(Eq{"a": 1}).Limit(5, 10).ForUpdate().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 WHERE ROWID IN (SELECT rid FROM (SELECT rid,ROWNUM RN FROM "+
+		"(SELECT ROWID rid FROM table1 WHERE a=:p1) at WHERE ROWNUM<=:p2) att WHERE att.RN>:p3) FOR UPDATE", sql)
+	assert.EqualValues(t, 3, len(args))
+
+	_, _, err = Oracle().Select("a").From("table1").Join("INNER", "table2", "table1.id=table2.id").
+		Limit(5).ForUpdate().ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
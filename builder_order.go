@@ -0,0 +1,151 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+)
+
+const (
+	nullsDefault = iota
+	nullsFirst
+	nullsLast
+)
+
+// Order describes an item of ORDER BY clause
+type Order struct {
+	expr      string
+	args      []interface{}
+	direction string
+	nulls     int
+	// raw expressions are never quoted
+	raw bool
+	// column names of Asc and Desc are checked when written
+	column bool
+}
+
+// Asc creates an ascending Order by a column name, an expression should be given as an Expr
+func Asc(col interface{}) Order {
+	return newOrder(col, "ASC")
+}
+
+// Desc creates a descending Order by a column name, an expression should be given as an Expr
+func Desc(col interface{}) Order {
+	return newOrder(col, "DESC")
+}
+
+func newOrder(col interface{}, direction string) Order {
+	switch t := col.(type) {
+	case string:
+		return Order{expr: t, direction: direction, column: true}
+	case expr:
+		return Order{expr: t.sql, args: t.args, direction: direction, raw: true}
+	}
+	// refused as an invalid column when written
+	return Order{direction: direction, column: true}
+}
+
+// NullsFirst sorts NULL values before non-NULL values
+func (order Order) NullsFirst() Order {
+	order.nulls = nullsFirst
+	return order
+}
+
+// NullsLast sorts NULL values after non-NULL values
+func (order Order) NullsLast() Order {
+	order.nulls = nullsLast
+	return order
+}
+
+// OrderBy appends items to ORDER BY clause, an item could be a raw string, an Order
+// created by Asc or Desc, or an Expr with args
+func (b *Builder) OrderBy(orders ...interface{}) *Builder {
+	for _, order := range orders {
+		switch t := order.(type) {
+		case string:
+			if len(t) > 0 {
+				b.orderBy = append(b.orderBy, Order{expr: t})
+			}
+		case Order:
+			b.orderBy = append(b.orderBy, t)
+		case expr:
+			b.orderBy = append(b.orderBy, Order{expr: t.sql, args: t.args, raw: true})
+		}
+	}
+	return b
+}
+
+func (order Order) exprWriteTo(w Writer) error {
+	if order.raw || len(order.args) > 0 {
+		// named placeholders are resolved as Expr
+		return expr{order.expr, order.args}.WriteTo(w)
+	}
+	if order.column {
+		if _, ok := quotePath(writerDialect(w), order.expr); !ok {
+			return ErrInvalidOrderColumn
+		}
+	}
+	_, err := fmt.Fprint(w, quoteName(w, order.expr))
+	return err
+}
+
+func (order Order) writeTo(w Writer, dialect string) error {
+	// MySQL and MSSQL don't support NULLS FIRST/LAST, sort by a NULL flag at first
+	if order.nulls != nullsDefault && lacks(dialect, FeatureNullsOrdering) {
+		var first, last = 0, 1
+		if order.nulls == nullsLast {
+			first, last = 1, 0
+		}
+
+		if _, err := fmt.Fprint(w, "CASE WHEN "); err != nil {
+			return err
+		}
+		if err := order.exprWriteTo(w); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, " IS NULL THEN %d ELSE %d END,", first, last); err != nil {
+			return err
+		}
+		order.nulls = nullsDefault
+	}
+
+	if err := order.exprWriteTo(w); err != nil {
+		return err
+	}
+
+	if len(order.direction) > 0 {
+		if _, err := fmt.Fprint(w, " ", order.direction); err != nil {
+			return err
+		}
+	}
+
+	switch order.nulls {
+	case nullsFirst:
+		if _, err := fmt.Fprint(w, " NULLS FIRST"); err != nil {
+			return err
+		}
+	case nullsLast:
+		if _, err := fmt.Fprint(w, " NULLS LAST"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orderByWriteTo writes items of ORDER BY clause without the keywords
+func (b *Builder) orderByWriteTo(w Writer) error {
+	for i, order := range b.orderBy {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := order.writeTo(w, b.dialect); err != nil {
+			return err
+		}
+	}
+	return nil
+}
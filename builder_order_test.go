@@ -0,0 +1,57 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_OrderBy(t *testing.T) {
+	sql, args, err := Select("c").From("table1").OrderBy(Asc("a"), Desc("b")).OrderBy("c").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT c FROM table1 ORDER BY a ASC,b DESC,c", sql)
+	assert.EqualValues(t, 0, len(args))
+
+	sql, args, err = Select("c").From("table1").Where(Eq{"d": 1}).
+		OrderBy(Desc(Expr("FIELD(c, ?, ?)", "x", "y")), Expr("LENGTH(c) > ?", 3)).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT c FROM table1 WHERE d=? ORDER BY FIELD(c, ?, ?) DESC,LENGTH(c) > ?", sql)
+	assert.EqualValues(t, []interface{}{1, "x", "y", 3}, args)
+
+	sql, _, err = Postgres().Select("c").From("table1").OrderBy(Asc("a").NullsFirst(), Desc("b").NullsLast()).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT c FROM table1 ORDER BY a ASC NULLS FIRST,b DESC NULLS LAST", sql)
+
+	// NULLS FIRST/LAST are emulated in MySQL and MSSQL
+	sql, _, err = MySQL().Select("c").From("table1").OrderBy(Asc("a").NullsFirst(), Desc("b").NullsLast()).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT c FROM table1 ORDER BY CASE WHEN a IS NULL THEN 0 ELSE 1 END,a ASC,"+
+		"CASE WHEN b IS NULL THEN 1 ELSE 0 END,b DESC", sql)
+
+	sql, args, err = MsSQL().Select("c").From("table1").OrderBy(Desc(Expr("c+?", 1)).NullsLast()).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT c FROM table1 ORDER BY CASE WHEN c+@p1 IS NULL THEN 1 ELSE 0 END,c+@p2 DESC", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	// ROW_NUMBER() follows the order of the query in MSSQL
+	sql, args, err = MsSQL().Select("a", "b").From("table1").Where(Eq{"a": 1}).
+		OrderBy(Desc("a"), Asc(Expr("b+?", 2))).Limit(5, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT TOP 5 a,b FROM (SELECT TOP 15 a,b,ROW_NUMBER() OVER (ORDER BY a DESC,b+@p1 ASC) AS RN "+
+		"FROM table1 WHERE a=@p2 ORDER BY a DESC,b+@p3 ASC) at WHERE at.RN>@p4 ORDER BY at.RN", sql)
+	assert.EqualValues(t, 4, len(args))
+
+	// Asc and Desc take column names, expressions should be Expr
+	sql, _, err = Postgres().Select("c").From("table1").OrderBy(Desc("t.a"), Asc(`"b c"`)).QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT "c" FROM "table1" ORDER BY "t"."a" DESC,"b c" ASC`, sql)
+
+	for _, order := range []Order{Asc("a; DROP TABLE t"), Desc("LENGTH(c)"), Asc(""), Desc(1)} {
+		_, _, err = Select("c").From("table1").OrderBy(order).ToSQL()
+		assert.EqualError(t, err, ErrInvalidOrderColumn.Error())
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"strings"
+	"unicode"
+)
+
+// QuoteIdentifiers makes the builder quote table names, column names and aliases
+// according to its dialect, expressions from Expr are kept as they are
+func (b *Builder) QuoteIdentifiers() *Builder {
+	b.quoted = true
+	return b
+}
+
+// ToQuotedSQL converts a builder or conditions to SQL and args, and quotes identifiers
+// in the given dialect
+func ToQuotedSQL(dialect string, cond interface{}) (string, []interface{}, error) {
+	switch t := cond.(type) {
+	case Cond:
+		if t == nil || !t.IsValid() {
+			return "", nil, nil
+		}
+
+		w := NewWriter()
+		w.dialect = dialect
+		w.quoted = true
+		if err := t.WriteTo(w); err != nil {
+			return "", nil, err
+		}
+		if err := checkParams(w.args); err != nil {
+			return "", nil, err
+		}
+		return w.toSQL(dialect)
+	case *Builder:
+		// the builder of the caller is kept as it is
+		b := *t
+		if b.dialect == "" {
+			b.dialect = dialect
+		} else if b.family() != dialectFamily(dialect) {
+			return "", nil, ErrInconsistentDialect
+		}
+		return b.QuoteIdentifiers().ToSQL()
+	}
+	return "", nil, ErrNotSupportType
+}
+
+// quoteIdentifier quotes an identifier by the dialect, or by double quotes of the standard
+func quoteIdentifier(dialect, name string) string {
+	if d := LookupDialect(dialect); d != nil {
+		return d.Quote(name)
+	}
+	return `"` + name + `"`
+}
+
+// isQuoted tests if an identifier has been quoted by one of the common quote characters
+func isQuoted(s string) bool {
+	if len(s) < 3 {
+		return false
+	}
+	switch s[0] {
+	case '"', '`':
+		return s[len(s)-1] == s[0]
+	case '[':
+		return s[len(s)-1] == ']'
+	}
+	return false
+}
+
+func isIdentifier(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && (r == '$' || unicode.IsDigit(r))) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// quotePath quotes a name like schema.table.col or alias.*, ok is false if it's not
+// a name (e.g. an expression)
+func quotePath(dialect, name string) (string, bool) {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		switch {
+		case part == "*" && i == len(parts)-1 && i > 0:
+		case isQuoted(part):
+			// quoted already
+		case len(parts) == 1 && (strings.EqualFold(part, "ROWNUM") || strings.EqualFold(part, "ROWID")):
+			// pseudo columns of Oracle
+		case isIdentifier(part):
+			parts[i] = quoteIdentifier(dialect, part)
+		default:
+			return name, false
+		}
+	}
+	return strings.Join(parts, "."), true
+}
+
+// quoteName quotes a column name if quoting is enabled on the writer
+func quoteName(w Writer, name string) string {
+	bw, ok := w.(*BytesWriter)
+	if !ok || !bw.quoted {
+		return name
+	}
+
+	quoted, _ := quotePath(bw.dialect, name)
+	return quoted
+}
+
+// quoteNames quotes every column name and joins them with comma
+func quoteNames(w Writer, names []string) string {
+	var quoted = make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, quoteName(w, name))
+	}
+	return strings.Join(quoted, ",")
+}
+
+// quoteTable quotes a table or column which could be followed by an alias, like
+// "table1 t" or "a AS b"
+func quoteTable(w Writer, name string) string {
+	bw, ok := w.(*BytesWriter)
+	if !ok || !bw.quoted {
+		return name
+	}
+
+	fields := strings.Fields(name)
+	// keep the modifiers of select columns
+	var prefix []string
+	for len(fields) > 1 {
+		if strings.EqualFold(fields[0], "DISTINCT") {
+			prefix, fields = append(prefix, fields[0]), fields[1:]
+		} else if strings.EqualFold(fields[0], "TOP") && len(fields) > 2 {
+			prefix, fields = append(prefix, fields[:2]...), fields[2:]
+		} else {
+			break
+		}
+	}
+	if len(prefix) > 0 {
+		return strings.Join(prefix, " ") + " " + quoteTable(w, strings.Join(fields, " "))
+	}
+
+	switch {
+	case len(fields) == 1:
+		quoted, _ := quotePath(bw.dialect, fields[0])
+		return quoted
+	case len(fields) == 2 || (len(fields) == 3 && strings.EqualFold(fields[1], "AS")):
+		alias := fields[len(fields)-1]
+		if !isIdentifier(alias) {
+			return name
+		}
+		quoted, ok := quotePath(bw.dialect, fields[0])
+		if !ok {
+			return name
+		}
+		fields[0] = quoted
+		fields[len(fields)-1], _ = quotePath(bw.dialect, alias)
+		return strings.Join(fields, " ")
+	}
+	return name
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	sql2 "database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_QuoteIdentifiers(t *testing.T) {
+	sql, args, err := MySQL().Select("a", "t.b AS c", "u.*", "count(*)").From("user u").
+		LeftJoin("order o", "o.uid=u.id").
+		Where(Eq{"u.order": 1}.And(In("o.id", 1, 2), Expr("x.y=?", 3), Like{"name", "a"})).
+		GroupBy("a", Expr("b")).OrderBy(Desc("a"), "b ASC").Limit(5).QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT `a`,`t`.`b` AS `c`,`u`.*,count(*) FROM `user` `u` LEFT JOIN `order` `o` ON o.uid=u.id "+
+		"WHERE `u`.`order`=? AND `o`.`id` IN (?,?) AND (x.y=?) AND `name` LIKE ? GROUP BY `a`,b ORDER BY `a` DESC,b ASC LIMIT 5", sql)
+	assert.EqualValues(t, []interface{}{1, 1, 2, 3, "%a%"}, args)
+
+	sql, args, err = Postgres().Select("Name").From("public.User").Where(Gt{"Age": 1}).
+		ForUpdate("User").QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT "Name" FROM "public"."User" WHERE "Age">$1 FOR UPDATE OF "User"`, sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	// nested builders are quoted as well
+	sql, _, err = Postgres().Select("a").From(Select("a").From("user"), "sub").
+		Where(Exists(Select("1").From("order").Where(Expr("order.uid=sub.a")))).QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT "a" FROM (SELECT "a" FROM "user") "sub" WHERE EXISTS (SELECT 1 FROM "order" WHERE order.uid=sub.a)`, sql)
+
+	sql, args, err = Postgres().Update(Eq{"order": 1}).From("user").Where(Eq{"id": 2}).Returning("id").QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `UPDATE "user" SET "order"=$1 WHERE "id"=$2 RETURNING "id"`, sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = MsSQL().Insert(Eq{"a": 1, "order": 2}).Into("user").Upsert([]string{"a"}, Eq{"order": 3}).
+		QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "MERGE INTO [user] USING (SELECT @p1 AS [a],@p2 AS [order]) AS src ON ([user].[a]=src.[a]) "+
+		"WHEN MATCHED THEN UPDATE SET [order]=@p3 WHEN NOT MATCHED THEN INSERT ([a],[order]) Values (src.[a],src.[order]);", sql)
+	assert.EqualValues(t, 3, len(args))
+
+	// pseudo columns of Oracle are not quoted when rewriting limit
+	sql, _, err = Oracle().Select("a").From("user").Where(Eq{"a": 1}).Limit(5).QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT "a" FROM (SELECT "a",ROWNUM "RN" FROM "user" WHERE "a"=:p1) "at" WHERE "at"."RN"<=:p2`, sql)
+}
+
+func TestToQuotedSQL(t *testing.T) {
+	sql, args, err := ToQuotedSQL(MSSQL, Eq{"user.name": "x"}.And(Between{"dbo.t.a", 1, 2}, IsNull{"[key]"}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "[user].[name]=@p1 AND [dbo].[t].[a] BETWEEN @p2 AND @p3 AND [key] IS NULL", sql)
+	assert.EqualValues(t, []interface{}{sql2.Named("p1", "x"), sql2.Named("p2", 1), sql2.Named("p3", 2)}, args)
+
+	// conditions are converted as builders are
+	sql, args, err = ToQuotedSQL(POSTGRES, Eq{"a": 1}.And(Expr("b ?? 'k'"), Eq{"order": 2}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `"a"=$1 AND (b ? 'k') AND "order"=$2`, sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	_, _, err = ToQuotedSQL(POSTGRES, Eq{"a": Param("a")})
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+
+	// the builder given is kept as it is
+	b := Select("a").From("group").Where(Neq{"b": 1})
+	sql, args, err = ToQuotedSQL(SQLITE, b)
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT "a" FROM "group" WHERE "b"<>?`, sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, _, err = b.ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM group WHERE b<>?", sql)
+	assert.EqualValues(t, "", b.dialect)
+
+	_, _, err = ToQuotedSQL(MYSQL, Postgres().Select("a").From("b"))
+	assert.EqualValues(t, ErrInconsistentDialect, err)
+}
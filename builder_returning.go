@@ -0,0 +1,119 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	sql2 "database/sql"
+	"fmt"
+	"strings"
+)
+
+type returning struct {
+	cols  []string
+	dests []interface{}
+}
+
+// Returning sets the columns which will be returned by an insert, update or delete statement.
+// It's rendered as RETURNING on Postgres and SQLite, OUTPUT on MSSQL and RETURNING ... INTO on
+// Oracle, MySQL is not supported. The columns are appended to those set before, and the
+// destinations set by ReturningInto are kept.
+func (b *Builder) Returning(cols ...string) *Builder {
+	if b.returning == nil {
+		b.returning = &returning{}
+	}
+	b.returning.cols = append(b.returning.cols, cols...)
+	return b
+}
+
+// ReturningInto sets the destinations of the output binds of Oracle's RETURNING ... INTO clause,
+// one destination per returning column. If it's not called, every column will be bound to
+// a new(interface{}) which could be retrieved from the args of ToSQL.
+func (b *Builder) ReturningInto(dests ...interface{}) *Builder {
+	if b.returning == nil {
+		b.returning = &returning{}
+	}
+	b.returning.dests = dests
+	return b
+}
+
+func (b *Builder) checkReturning() error {
+	if b.returning == nil {
+		return nil
+	}
+
+	if len(b.returning.cols) == 0 {
+		return ErrNoColumnToReturn
+	}
+
+	if b.dialect == "" {
+		return ErrDialectNotSetUp
+	}
+
+	if d := LookupDialect(b.dialect); d == nil || !d.Supports(FeatureReturning) {
+		return ErrNotSupportDialectType
+	}
+
+	if b.family() == ORACLE && len(b.returning.dests) > 0 && len(b.returning.dests) != len(b.returning.cols) {
+		return ErrNeedMoreArguments
+	}
+	return nil
+}
+
+// outputWriteTo writes MSSQL's OUTPUT clause, prefix should be INSERTED or DELETED
+func (b *Builder) outputWriteTo(w Writer, prefix string) error {
+	if b.returning == nil || b.family() != MSSQL {
+		return nil
+	}
+
+	if _, err := fmt.Fprint(w, " OUTPUT "); err != nil {
+		return err
+	}
+
+	for i, col := range b.returning.cols {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s.%s", prefix, quoteName(w, col)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returningWriteTo writes the trailing RETURNING clause, which is RETURNING INTO in Oracle
+func (b *Builder) returningWriteTo(w Writer) error {
+	if b.returning == nil {
+		return nil
+	}
+
+	switch b.family() {
+	case MSSQL:
+		// written as OUTPUT clause instead
+		return nil
+	case ORACLE:
+		questionMark := strings.Repeat("?,", len(b.returning.cols))
+		if _, err := fmt.Fprintf(w, " RETURNING %s INTO %s", quoteNames(w, b.returning.cols),
+			questionMark[:len(questionMark)-1]); err != nil {
+			return err
+		}
+
+		for i := range b.returning.cols {
+			var dest interface{}
+			if len(b.returning.dests) > 0 {
+				dest = b.returning.dests[i]
+			} else {
+				dest = new(interface{})
+			}
+			w.Append(sql2.Out{Dest: dest})
+		}
+		return nil
+	}
+
+	_, err := fmt.Fprint(w, " RETURNING ", quoteNames(w, b.returning.cols))
+	return err
+}
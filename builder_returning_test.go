@@ -0,0 +1,80 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	sql2 "database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Returning(t *testing.T) {
+	sql, args, err := Postgres().Insert(Eq{"a": 1, "b": 2}).Into("table1").Returning("id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (a,b) Values ($1,$2) RETURNING id", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = SQLite().Update(Eq{"a": 2}).From("table1").Where(Eq{"b": 1}).Returning("id", "a").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=? WHERE b=? RETURNING id,a", sql)
+	assert.EqualValues(t, []interface{}{2, 1}, args)
+
+	sql, args, err = Postgres().Insert(Eq{"id": 1, "a": 1}).Into("table1").
+		Upsert([]string{"id"}, Eq{"a": 1}).Returning("id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (a,id) Values ($1,$2) ON CONFLICT (id) DO UPDATE SET a=$3 RETURNING id", sql)
+	assert.EqualValues(t, []interface{}{1, 1, 1}, args)
+
+	sql, err = MsSQL().Insert(Eq{"a": 1, "b": 2}).Into("table1").Returning("id").ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (a,b) OUTPUT INSERTED.id Values (1,2)", sql)
+
+	sql, err = MsSQL().Insert("a").Into("table1").Select("a").From("table2").Returning("id").ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (a) OUTPUT INSERTED.id SELECT a FROM table2", sql)
+
+	sql, err = MsSQL().Update(Eq{"a": 2}).From("table1").Where(Eq{"b": 1}).Returning("id", "a").ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=2 OUTPUT INSERTED.id,INSERTED.a WHERE b=1", sql)
+
+	sql, err = MsSQL().Delete(Eq{"b": 1}).From("table1").Returning("id").ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 OUTPUT DELETED.id WHERE b=1", sql)
+
+	sql, err = MsSQL().Insert(Eq{"id": 1}).Into("table1").Upsert([]string{"id"}).Returning("id").ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "MERGE INTO table1 USING (SELECT 1 AS id) AS src ON (table1.id=src.id) "+
+		"WHEN NOT MATCHED THEN INSERT (id) Values (src.id) OUTPUT INSERTED.id;", sql)
+
+	var id int64
+	sql, args, err = Oracle().Delete(Eq{"b": 1}).From("table1").Returning("id").ReturningInto(&id).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 WHERE b=:p1 RETURNING id INTO :p2", sql)
+	assert.EqualValues(t, []interface{}{sql2.Named("p1", 1), sql2.Named("p2", sql2.Out{Dest: &id})}, args)
+
+	// the columns are merged and the destinations are kept
+	var a string
+	sql, args, err = Oracle().Delete(Eq{"b": 1}).From("table1").ReturningInto(&id, &a).Returning("id").Returning("a").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "DELETE FROM table1 WHERE b=:p1 RETURNING id,a INTO :p2,:p3", sql)
+	assert.EqualValues(t, []interface{}{sql2.Named("p1", 1), sql2.Named("p2", sql2.Out{Dest: &id}),
+		sql2.Named("p3", sql2.Out{Dest: &a})}, args)
+
+	_, _, err = Oracle().Delete(Eq{"b": 1}).From("table1").Returning("id", "a").ReturningInto(&id).ToSQL()
+	assert.EqualValues(t, ErrNeedMoreArguments, err)
+
+	_, _, err = Oracle().Insert("a").Into("table1").Select("a").From("table2").Returning("id").ToSQL()
+	assert.EqualValues(t, ErrNotSupportType, err)
+
+	_, _, err = MySQL().Insert(Eq{"a": 1}).Into("table1").Returning("id").ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = Delete(Eq{"a": 1}).From("table1").Returning("id").ToSQL()
+	assert.EqualValues(t, ErrDialectNotSetUp, err)
+
+	_, _, err = Postgres().Delete(Eq{"a": 1}).From("table1").Returning().ToSQL()
+	assert.EqualValues(t, ErrNoColumnToReturn, err)
+}
@@ -0,0 +1,274 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxCursorLen is the longest token accepted by DecodeCursor
+	maxCursorLen = 4096
+	// maxCursorValues is the most key values in a token
+	maxCursorValues = 16
+)
+
+// Seek paginates the query by keyset instead of offset. keys are the columns to order the rows,
+// which should identify a row uniquely together and never be NULL. after are the values of keys
+// in the last row of the previous page, it's empty for the first page. The rows are ordered by
+// keys after the existing ORDER BY items, which should be none in general.
+func (b *Builder) Seek(keys []Order, after []interface{}, limitN int) *Builder {
+	if len(keys) == 0 || len(after) > 0 {
+		b.Where(condSeek{keys: keys, values: after})
+	}
+	b.orderBy = append(b.orderBy, keys...)
+	return b.Limit(limitN)
+}
+
+type condSeek struct {
+	keys   []Order
+	values []interface{}
+}
+
+var _ Cond = condSeek{}
+
+func (s condSeek) WriteTo(w Writer) error {
+	if len(s.keys) == 0 {
+		return ErrNoKeyset
+	}
+	if len(s.values) != len(s.keys) {
+		return ErrInconsistentKeyset
+	}
+
+	if len(s.keys) > 1 && s.sameDirection() && !lacks(writerDialect(w), FeatureRowValues) {
+		return s.rowValueWriteTo(w)
+	}
+
+	// a>? OR (a=? AND b>?) OR (a=? AND b=? AND c>?)
+	if len(s.keys) > 1 {
+		if _, err := fmt.Fprint(w, "("); err != nil {
+			return err
+		}
+	}
+	for i, key := range s.keys {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, " OR ("); err != nil {
+				return err
+			}
+		}
+		for j := 0; j < i; j++ {
+			if err := s.keys[j].exprWriteTo(w); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprint(w, "=? AND "); err != nil {
+				return err
+			}
+			w.Append(s.values[j])
+		}
+		if err := key.exprWriteTo(w); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, seekOperator(key), "?"); err != nil {
+			return err
+		}
+		w.Append(s.values[i])
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ")"); err != nil {
+				return err
+			}
+		}
+	}
+	if len(s.keys) > 1 {
+		if _, err := fmt.Fprint(w, ")"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowValueWriteTo writes the condition as a comparison of row values, e.g. (a,b)>(?,?)
+func (s condSeek) rowValueWriteTo(w Writer) error {
+	if _, err := fmt.Fprint(w, "("); err != nil {
+		return err
+	}
+	for i, key := range s.keys {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := key.exprWriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprint(w, ")", seekOperator(s.keys[0]), "("); err != nil {
+		return err
+	}
+	for i := range s.values {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprint(w, "?"); err != nil {
+			return err
+		}
+	}
+	w.Append(s.values...)
+
+	_, err := fmt.Fprint(w, ")")
+	return err
+}
+
+func (s condSeek) sameDirection() bool {
+	for _, key := range s.keys[1:] {
+		if seekOperator(key) != seekOperator(s.keys[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s condSeek) And(conds ...Cond) Cond {
+	return And(s, And(conds...))
+}
+
+func (s condSeek) Or(conds ...Cond) Cond {
+	return Or(s, Or(conds...))
+}
+
+func (s condSeek) IsValid() bool {
+	// invalid keysets are reported when written
+	return true
+}
+
+// seekOperator returns the operator to find the rows after a value of the key
+func seekOperator(key Order) string {
+	if key.direction == "DESC" {
+		return "<"
+	}
+	return ">"
+}
+
+// cursorValue is a key value in a cursor with its kind, values are kept as strings so integers
+// and times aren't rounded by JSON numbers
+type cursorValue struct {
+	Kind  string `json:"k"`
+	Value string `json:"v,omitempty"`
+}
+
+// EncodeCursor encodes the key values of the last row in a page as an opaque token, which could
+// be passed to clients to request the next page. Integers, floats, strings, bools, times, bytes
+// and nil are supported. The token isn't signed or encrypted, callers should sign it or validate
+// the decoded values before seeking by them.
+func EncodeCursor(values ...interface{}) (string, error) {
+	if len(values) > maxCursorValues {
+		return "", ErrInvalidCursor
+	}
+
+	var cvs = make([]cursorValue, 0, len(values))
+	for _, value := range values {
+		cv, err := encodeCursorValue(value)
+		if err != nil {
+			return "", err
+		}
+		cvs = append(cvs, cv)
+	}
+
+	data, err := json.Marshal(cvs)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func encodeCursorValue(value interface{}) (cursorValue, error) {
+	switch t := value.(type) {
+	case nil:
+		return cursorValue{Kind: "nil"}, nil
+	case time.Time:
+		return cursorValue{Kind: "time", Value: t.Format(time.RFC3339Nano)}, nil
+	case []byte:
+		if t == nil {
+			return cursorValue{Kind: "nil"}, nil
+		}
+		return cursorValue{Kind: "bytes", Value: base64.RawURLEncoding.EncodeToString(t)}, nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cursorValue{Kind: "int", Value: strconv.FormatInt(v.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cursorValue{Kind: "uint", Value: strconv.FormatUint(v.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return cursorValue{Kind: "float", Value: strconv.FormatFloat(v.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return cursorValue{Kind: "string", Value: v.String()}, nil
+	case reflect.Bool:
+		return cursorValue{Kind: "bool", Value: strconv.FormatBool(v.Bool())}, nil
+	}
+	return cursorValue{}, ErrNotSupportType
+}
+
+// DecodeCursor decodes the key values from a token of EncodeCursor to seek the next page.
+// An empty token is decoded as no values, which means the first page. Integers are decoded
+// as int64 or uint64 and floats as float64.
+func DecodeCursor(cursor string) ([]interface{}, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	if len(cursor) > maxCursorLen {
+		return nil, ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cvs []cursorValue
+	if err := json.Unmarshal(data, &cvs); err != nil || len(cvs) == 0 || len(cvs) > maxCursorValues {
+		return nil, ErrInvalidCursor
+	}
+
+	var values = make([]interface{}, 0, len(cvs))
+	for _, cv := range cvs {
+		value, err := decodeCursorValue(cv)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func decodeCursorValue(cv cursorValue) (interface{}, error) {
+	switch cv.Kind {
+	case "nil":
+		return nil, nil
+	case "time":
+		return time.Parse(time.RFC3339Nano, cv.Value)
+	case "bytes":
+		return base64.RawURLEncoding.DecodeString(cv.Value)
+	case "int":
+		return strconv.ParseInt(cv.Value, 10, 64)
+	case "uint":
+		return strconv.ParseUint(cv.Value, 10, 64)
+	case "float":
+		return strconv.ParseFloat(cv.Value, 64)
+	case "string":
+		return cv.Value, nil
+	case "bool":
+		return strconv.ParseBool(cv.Value)
+	}
+	return nil, ErrInvalidCursor
+}
@@ -0,0 +1,101 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Seek(t *testing.T) {
+	keys := []Order{Desc("created"), Desc("id")}
+
+	sql, args, err := Postgres().Select("id").From("post").Where(Eq{"status": 1}).Seek(keys, nil, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM post WHERE status=$1 ORDER BY created DESC,id DESC LIMIT 10", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, args, err = Postgres().Select("id").From("post").Where(Eq{"status": 1}).
+		Seek(keys, []interface{}{"2019-01-02", 7}, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM post WHERE status=$1 AND (created,id)<($2,$3) ORDER BY created DESC,id DESC LIMIT 10", sql)
+	assert.EqualValues(t, []interface{}{1, "2019-01-02", 7}, args)
+
+	// mixed directions can't be compared as row values
+	sql, args, err = MySQL().Select("id").From("post").Where(Eq{"status": 1}).
+		Seek([]Order{Asc("a"), Desc("b"), Asc("id")}, []interface{}{1, 2, 3}, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM post WHERE status=? AND (a>? OR (a=? AND b<?) OR (a=? AND b=? AND id>?)) "+
+		"ORDER BY a ASC,b DESC,id ASC LIMIT 10", sql)
+	assert.EqualValues(t, []interface{}{1, 1, 1, 2, 1, 2, 3}, args)
+
+	sql, args, err = Dialect(MSSQL2012).Select("id").From("post").
+		Seek(keys, []interface{}{"2019-01-02", 7}, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM post WHERE (created<@p1 OR (created=@p2 AND id<@p3)) "+
+		"ORDER BY created DESC,id DESC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY", sql)
+	assert.EqualValues(t, 3, len(args))
+
+	// ROWNUM is assigned before ORDER BY, so the ordered rows are numbered outside
+	sql, args, err = Oracle().Select("id").From("post").Seek([]Order{Desc("id")}, []interface{}{7}, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM (SELECT at.*,ROWNUM RN FROM (SELECT id FROM post WHERE id<:p1 ORDER BY id DESC) at) at "+
+		"WHERE at.RN<=:p2", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	sql, args, err = SQLite().Select("id").From("post").Seek([]Order{Asc("id")}, []interface{}{7}, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM post WHERE id>? ORDER BY id ASC LIMIT 10", sql)
+	assert.EqualValues(t, []interface{}{7}, args)
+
+	_, _, err = SQLite().Select("id").From("post").Seek(keys, []interface{}{7}, 10).ToSQL()
+	assert.EqualError(t, err, ErrInconsistentKeyset.Error())
+
+	_, _, err = SQLite().Select("id").From("post").Seek(nil, nil, 10).ToSQL()
+	assert.EqualError(t, err, ErrNoKeyset.Error())
+}
+
+func TestCursor(t *testing.T) {
+	created := time.Date(2019, 1, 2, 3, 4, 5, 6, time.UTC)
+	cursor, err := EncodeCursor(created, int64(7), "a")
+	assert.NoError(t, err)
+
+	values, err := DecodeCursor(cursor)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{created, int64(7), "a"}, values)
+
+	values, err = DecodeCursor("")
+	assert.NoError(t, err)
+	assert.Nil(t, values)
+
+	_, err = DecodeCursor("not a cursor")
+	assert.EqualError(t, err, ErrInvalidCursor.Error())
+	_, err = DecodeCursor("bm90IGEgY3Vyc29y")
+	assert.EqualError(t, err, ErrInvalidCursor.Error())
+
+	cursor, err = EncodeCursor(int32(-1), uint(2), 1.5, true, []byte("b"), nil)
+	assert.NoError(t, err)
+	values, err = DecodeCursor(cursor)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{int64(-1), uint64(2), 1.5, true, []byte("b"), nil}, values)
+
+	// only the tagged kinds are accepted
+	_, err = EncodeCursor(struct{}{})
+	assert.EqualError(t, err, ErrNotSupportType.Error())
+	_, err = DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte(`[{"k":"func","v":"x"}]`)))
+	assert.EqualError(t, err, ErrInvalidCursor.Error())
+	_, err = DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte(`[{"k":"int","v":"1.5"}]`)))
+	assert.EqualError(t, err, ErrInvalidCursor.Error())
+
+	// the tokens are bounded
+	_, err = EncodeCursor(make([]interface{}, maxCursorValues+1)...)
+	assert.EqualError(t, err, ErrInvalidCursor.Error())
+	_, err = DecodeCursor(strings.Repeat("a", maxCursorLen+1))
+	assert.EqualError(t, err, ErrInvalidCursor.Error())
+}
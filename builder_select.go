@@ -18,10 +18,13 @@ func (b *Builder) selectWriteTo(w Writer) error {
 	if len(b.from) <= 0 && !b.isNested {
 		return ErrNoTableName
 	}
+	if err := b.checkLock(); err != nil {
+		return err
+	}
 
 	// perform limit before writing to writer when b.dialect between ORACLE and MSSQL
 	// this avoid a duplicate writing problem in simple limit query
-	if b.limitation != nil && (b.dialect == ORACLE || b.dialect == MSSQL) {
+	if b.limitation != nil && b.rewriteLimit() {
 		return b.limitWriteTo(w)
 	}
 
@@ -30,7 +33,7 @@ func (b *Builder) selectWriteTo(w Writer) error {
 	}
 	if len(b.selects) > 0 {
 		for i, s := range b.selects {
-			if _, err := fmt.Fprint(w, s); err != nil {
+			if _, err := fmt.Fprint(w, quoteTable(w, s)); err != nil {
 				return err
 			}
 			if i != len(b.selects)-1 {
@@ -44,16 +47,21 @@ func (b *Builder) selectWriteTo(w Writer) error {
 			return err
 		}
 	}
+	w.Append(b.selectArgs...)
 
 	if b.subQuery == nil {
-		if _, err := fmt.Fprint(w, " FROM ", b.from); err != nil {
+		if _, err := fmt.Fprint(w, " FROM ", quoteTable(w, b.from)); err != nil {
+			return err
+		}
+
+		if err := b.lockHintWriteTo(w); err != nil {
 			return err
 		}
 	} else {
 		if b.cond.IsValid() && len(b.from) <= 0 {
 			return ErrUnnamedDerivedTable
 		}
-		if b.subQuery.dialect != "" && b.dialect != b.subQuery.dialect {
+		if b.subQuery.dialect != "" && b.family() != b.subQuery.family() {
 			return ErrInconsistentDialect
 		}
 
@@ -72,7 +80,7 @@ func (b *Builder) selectWriteTo(w Writer) error {
 			if len(b.from) == 0 {
 				fmt.Fprintf(w, ")")
 			} else {
-				fmt.Fprintf(w, ") %v", b.from)
+				fmt.Fprintf(w, ") %v", quoteName(w, b.from))
 			}
 		default:
 			return ErrUnexpectedSubQuery
@@ -80,11 +88,7 @@ func (b *Builder) selectWriteTo(w Writer) error {
 	}
 
 	for _, v := range b.joins {
-		if _, err := fmt.Fprintf(w, " %s JOIN %s ON ", v.joinType, v.joinTable); err != nil {
-			return err
-		}
-
-		if err := v.joinCond.WriteTo(w); err != nil {
+		if err := b.joinWriteTo(w, v); err != nil {
 			return err
 		}
 	}
@@ -100,19 +104,31 @@ func (b *Builder) selectWriteTo(w Writer) error {
 	}
 
 	if len(b.groupBy) > 0 {
-		if _, err := fmt.Fprint(w, " GROUP BY ", b.groupBy); err != nil {
+		if _, err := fmt.Fprint(w, " GROUP BY "); err != nil {
+			return err
+		}
+
+		if err := b.groupByWriteTo(w); err != nil {
 			return err
 		}
 	}
 
-	if len(b.having) > 0 {
-		if _, err := fmt.Fprint(w, " HAVING ", b.having); err != nil {
+	if b.having != nil && b.having.IsValid() {
+		if _, err := fmt.Fprint(w, " HAVING "); err != nil {
+			return err
+		}
+
+		if err := b.having.WriteTo(w); err != nil {
 			return err
 		}
 	}
 
 	if len(b.orderBy) > 0 {
-		if _, err := fmt.Fprint(w, " ORDER BY ", b.orderBy); err != nil {
+		if _, err := fmt.Fprint(w, " ORDER BY "); err != nil {
+			return err
+		}
+
+		if err := b.orderByWriteTo(w); err != nil {
 			return err
 		}
 	}
@@ -123,23 +139,5 @@ func (b *Builder) selectWriteTo(w Writer) error {
 		}
 	}
 
-	return nil
-}
-
-// OrderBy orderBy SQL
-func (b *Builder) OrderBy(orderBy string) *Builder {
-	b.orderBy = orderBy
-	return b
-}
-
-// GroupBy groupby SQL
-func (b *Builder) GroupBy(groupby string) *Builder {
-	b.groupBy = groupby
-	return b
-}
-
-// Having having SQL
-func (b *Builder) Having(having string) *Builder {
-	b.having = having
-	return b
+	return b.lockWriteTo(w)
 }
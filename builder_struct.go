@@ -0,0 +1,184 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FieldTag is the mapping of a struct field given by its db tag like `db:"col,omitempty,pk,readonly"`
+type FieldTag struct {
+	// Name is the column name, which is the field name if the tag has no name
+	Name string
+	// Ignored is true if the tag is "-"
+	Ignored bool
+	// Inline is true if the field is embedded without a name, whose fields are mapped
+	// instead if it's a struct
+	Inline    bool
+	OmitEmpty bool
+	Pk        bool
+	ReadOnly  bool
+}
+
+// ParseFieldTag parses the db tag of a struct field, which is shared by the packages mapping
+// columns to struct fields
+func ParseFieldTag(field reflect.StructField) FieldTag {
+	tags := strings.Split(field.Tag.Get("db"), ",")
+	tag := FieldTag{
+		Name:    tags[0],
+		Ignored: tags[0] == "-",
+		Inline:  field.Anonymous && len(tags[0]) == 0,
+	}
+	if len(tag.Name) == 0 {
+		tag.Name = field.Name
+	}
+	for _, option := range tags[1:] {
+		switch strings.TrimSpace(option) {
+		case "omitempty":
+			tag.OmitEmpty = true
+		case "pk":
+			tag.Pk = true
+		case "readonly":
+			tag.ReadOnly = true
+		}
+	}
+	return tag
+}
+
+// structColumn is a column mapped from a struct field by its db tag, fields without tags are
+// mapped by their names
+type structColumn struct {
+	name      string
+	value     interface{}
+	zero      bool
+	omitempty bool
+	pk        bool
+	readonly  bool
+}
+
+// insertable tests if the column should be written by INSERT or as a value of UPDATE
+func (col structColumn) insertable() bool {
+	return !col.readonly && !(col.omitempty && col.zero)
+}
+
+// structValue returns the struct which bean is or points to
+func structValue(bean interface{}) (reflect.Value, bool) {
+	switch bean.(type) {
+	case driver.Valuer, time.Time, *time.Time:
+		return reflect.Value{}, false
+	}
+
+	v := reflect.ValueOf(bean)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+// structColumns returns the columns mapped from the fields of a struct, fields of
+// embedded structs are mapped as well
+func structColumns(v reflect.Value) []structColumn {
+	var cols []structColumn
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := ParseFieldTag(field)
+		if tag.Ignored {
+			continue
+		}
+
+		if tag.Inline {
+			embedded := v.Field(i)
+			for embedded.Kind() == reflect.Ptr && !embedded.IsNil() {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				cols = append(cols, structColumns(embedded)...)
+				continue
+			}
+		}
+
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+
+		col := structColumn{
+			name:      tag.Name,
+			value:     v.Field(i).Interface(),
+			zero:      v.Field(i).IsZero(),
+			omitempty: tag.OmitEmpty,
+			pk:        tag.Pk,
+			readonly:  tag.ReadOnly,
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// structEq converts a struct to an Eq of its insertable columns, and a slice of structs to []Eq,
+// other values are returned as they are
+func structEq(bean interface{}) interface{} {
+	if v, ok := structValue(bean); ok {
+		var eq = make(Eq)
+		for _, col := range structColumns(v) {
+			if col.insertable() {
+				eq[col.name] = col.value
+			}
+		}
+		return eq
+	}
+
+	v := reflect.ValueOf(bean)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return bean
+	}
+	if _, ok := structValue(v.Index(0).Interface()); !ok {
+		return bean
+	}
+
+	var rows = make([]Eq, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		row, ok := structEq(v.Index(i).Interface()).(Eq)
+		if !ok {
+			return bean
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// UpdateStruct creates an update Builder from a struct
+func UpdateStruct(bean interface{}) *Builder {
+	builder := &Builder{cond: NewCond()}
+	return builder.UpdateStruct(bean)
+}
+
+// UpdateStruct sets update SQL from a struct or a pointer to struct with db tags. The fields
+// marked as pk are the conditions, and the others are updated except the readonly and the
+// empty omitempty ones. A struct without pk fields is refused by ErrNoPrimaryKey
+func (b *Builder) UpdateStruct(bean interface{}) *Builder {
+	v, ok := structValue(bean)
+	if !ok {
+		return b.Update()
+	}
+
+	var updates, keys = make(Eq), make(Eq)
+	for _, col := range structColumns(v) {
+		if col.pk {
+			keys[col.name] = col.value
+		} else if col.insertable() {
+			updates[col.name] = col.value
+		}
+	}
+
+	b.Update(updates)
+	if len(keys) == 0 {
+		b.missingPk = true
+		return b
+	}
+	return b.Where(keys)
+}
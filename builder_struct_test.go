@@ -0,0 +1,89 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type structModel struct {
+	Created time.Time `db:"created,readonly"`
+}
+
+type structUser struct {
+	structModel
+	ID       int64  `db:"id,pk,readonly"`
+	Name     string `db:"name"`
+	Nickname string `db:"nickname,omitempty"`
+	Age      int
+	Password string `db:"-"`
+	secret   string
+}
+
+func TestBuilder_InsertStruct(t *testing.T) {
+	user := structUser{ID: 1, Name: "a", Age: 18, Password: "x", secret: "y"}
+	sql, args, err := Insert(&user).Into("user").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO user (Age,name) Values (?,?)", sql)
+	assert.EqualValues(t, []interface{}{18, "a"}, args)
+
+	sql, args, err = MySQL().Insert([]structUser{
+		{Name: "a", Nickname: "aa", Age: 18},
+		{Name: "b", Nickname: "bb", Age: 19},
+	}).Into("user").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO user (Age,name,nickname) Values (?,?,?),(?,?,?)", sql)
+	assert.EqualValues(t, []interface{}{18, "a", "aa", 19, "b", "bb"}, args)
+
+	// omitted columns make rows inconsistent
+	_, _, err = MySQL().Insert([]structUser{{Name: "a", Nickname: "aa"}, {Name: "b"}}).Into("user").ToSQL()
+	assert.EqualError(t, err, ErrInconsistentInsertRows.Error())
+}
+
+func TestBuilder_UpdateStruct(t *testing.T) {
+	user := structUser{ID: 1, Name: "a", Age: 18}
+	sql, args, err := UpdateStruct(user).From("user").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE user SET Age=?,name=? WHERE id=?", sql)
+	assert.EqualValues(t, []interface{}{18, "a", int64(1)}, args)
+
+	sql, args, err = Postgres().UpdateStruct(&structUser{ID: 2, Nickname: "b"}).From("user").Where(Eq{"Age": 0}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE user SET Age=$1,name=$2,nickname=$3 WHERE id=$4 AND Age=$5", sql)
+	assert.EqualValues(t, []interface{}{0, "", "b", int64(2), 0}, args)
+
+	_, _, err = UpdateStruct(1).From("user").ToSQL()
+	assert.EqualError(t, err, ErrNoColumnToUpdate.Error())
+
+	// a struct without pk would update all rows
+	type profile struct {
+		Name string `db:"name"`
+	}
+	_, _, err = UpdateStruct(profile{Name: "a"}).From("user").ToSQL()
+	assert.EqualError(t, err, ErrNoPrimaryKey.Error())
+	_, _, err = UpdateStruct(profile{Name: "a"}).From("user").Where(Eq{"id": 1}).ToSQL()
+	assert.EqualError(t, err, ErrNoPrimaryKey.Error())
+}
+
+func TestParseFieldTag(t *testing.T) {
+	typ := reflect.TypeOf(structUser{})
+	var tags []FieldTag
+	for i := 0; i < typ.NumField(); i++ {
+		tags = append(tags, ParseFieldTag(typ.Field(i)))
+	}
+	assert.EqualValues(t, []FieldTag{
+		{Name: "structModel", Inline: true},
+		{Name: "id", Pk: true, ReadOnly: true},
+		{Name: "name"},
+		{Name: "nickname", OmitEmpty: true},
+		{Name: "Age"},
+		{Name: "-", Ignored: true},
+		{Name: "secret"},
+	}, tags)
+}
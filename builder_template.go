@@ -0,0 +1,86 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	sql2 "database/sql"
+)
+
+// Param is a placeholder of a value which is bound later by Template.Bind, it could be used
+// anywhere a single value is accepted. ToSQL and ToBoundSQL refuse Params by ErrUnboundParam
+type Param string
+
+// checkParams refuses Params in args, which are only bound by Template.Bind
+func checkParams(args []interface{}) error {
+	for _, arg := range args {
+		if namedArg, ok := arg.(sql2.NamedArg); ok {
+			arg = namedArg.Value
+		}
+		if _, ok := arg.(Param); ok {
+			return ErrUnboundParam
+		}
+	}
+	return nil
+}
+
+// Template is a compiled builder, whose SQL is rendered once and args are bound for every execution
+type Template struct {
+	sql    string
+	args   []interface{}
+	params []templateParam
+}
+
+type templateParam struct {
+	index int
+	param Param
+	// name of the sql.NamedArg which wraps the param, e.g. in MSSQL and Oracle
+	named string
+}
+
+// Compile renders the SQL of the builder once, the values of Params are bound by Bind later
+func (b *Builder) Compile() (*Template, error) {
+	sql, args, err := b.toSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &Template{sql: sql, args: args}
+	for i, arg := range args {
+		switch t := arg.(type) {
+		case Param:
+			tmpl.params = append(tmpl.params, templateParam{index: i, param: t})
+		case sql2.NamedArg:
+			if param, ok := t.Value.(Param); ok {
+				tmpl.params = append(tmpl.params, templateParam{index: i, param: param, named: t.Name})
+			}
+		}
+	}
+	return tmpl, nil
+}
+
+// SQL returns the rendered SQL
+func (tmpl *Template) SQL() string {
+	return tmpl.sql
+}
+
+// Bind returns the args in order for an execution, Params are replaced by the values of their names
+func (tmpl *Template) Bind(values map[string]interface{}) ([]interface{}, error) {
+	var args = make([]interface{}, len(tmpl.args))
+	copy(args, tmpl.args)
+
+	for _, p := range tmpl.params {
+		value, ok := values[string(p.param)]
+		if !ok {
+			return nil, ErrUnboundParam
+		}
+
+		if len(p.named) > 0 {
+			args[p.index] = sql2.Named(p.named, value)
+		} else {
+			args[p.index] = value
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	sql2 "database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Compile(t *testing.T) {
+	tmpl, err := Postgres().Select("id").From("user").
+		Where(Eq{"id": Param("userID"), "status": 1}.And(In("role", Param("role"), "admin"), Expr("age>?", Param("age")))).
+		Limit(10).Compile()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM user WHERE id=$1 AND status=$2 AND role IN ($3,$4) AND (age>$5) LIMIT 10", tmpl.SQL())
+
+	args, err := tmpl.Bind(map[string]interface{}{"userID": 3, "role": "guest", "age": 18})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{3, 1, "guest", "admin", 18}, args)
+
+	args, err = tmpl.Bind(map[string]interface{}{"userID": 4, "role": "user", "age": 20})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{4, 1, "user", "admin", 20}, args)
+
+	_, err = tmpl.Bind(map[string]interface{}{"userID": 4})
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+
+	tmpl, err = MsSQL().Update(Eq{"name": Param("name")}).From("user").Where(Eq{"id": Param("id")}).Compile()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE user SET name=@p1 WHERE id=@p2", tmpl.SQL())
+
+	args, err = tmpl.Bind(map[string]interface{}{"name": "x", "id": 1})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{sql2.Named("p1", "x"), sql2.Named("p2", 1)}, args)
+
+	_, err = Select("id").Compile()
+	assert.Error(t, err)
+
+	// unbound params never reach the database
+	query := MsSQL().Select("id").From("user").Where(Eq{"id": Param("userID")})
+	_, _, err = query.ToSQL()
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+	_, err = query.ToBoundSQL()
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+	_, _, err = query.ToNamedSQL()
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+	_, _, err = ToSQL(Eq{"id": Param("userID")})
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+	_, err = ToBoundSQL(Eq{"id": Param("userID")})
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+}
+
+func BenchmarkBuilder_ToSQL(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Postgres().Select("id", "name").From("user").
+			Where(Eq{"id": i, "status": 1}.And(In("role", "guest", "admin"))).Limit(10).ToSQL()
+	}
+}
+
+func BenchmarkTemplate_Bind(b *testing.B) {
+	tmpl, err := Postgres().Select("id", "name").From("user").
+		Where(Eq{"id": Param("id"), "status": 1}.And(In("role", "guest", "admin"))).Limit(10).Compile()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tmpl.Bind(map[string]interface{}{"id": i})
+	}
+}
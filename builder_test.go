@@ -5,6 +5,7 @@
 package builder
 
 import (
+	sql2 "database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -620,6 +621,74 @@ func TestExprCond(t *testing.T) {
 	assert.EqualValues(t, []interface{}{1, 2, 3, 4}, args)
 }
 
+func TestExprCond_Named(t *testing.T) {
+	sql, args, err := Postgres().Select("id").From("table1").
+		Where(Expr("a=:a OR b=@b OR c::text=:a OR d=':e' OR f ??| :g", map[string]interface{}{"a": 1, "b": 2, "g": "x"})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM table1 WHERE a=$1 OR b=$2 OR c::text=$3 OR d=':e' OR f ?| $4", sql)
+	assert.EqualValues(t, []interface{}{1, 2, 1, "x"}, args)
+
+	type embedded struct {
+		Status int
+	}
+	type user struct {
+		embedded
+		ID   int64 `db:"user_id"`
+		Name string
+	}
+	sql, args, err = Select("id").From("table1").
+		Where(Expr("user_id=:user_id AND name=:name AND status=:status", user{embedded{3}, 1, "x"})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM table1 WHERE user_id=? AND name=? AND status=?", sql)
+	assert.EqualValues(t, []interface{}{int64(1), "x", 3}, args)
+
+	sql, args, err = ToSQL(Expr("a=:a AND b=:b", sql2.Named("b", 2), sql2.Named("a", 1)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "a=? AND b=?", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	// positional placeholders are kept as they are
+	sql, args, err = ToSQL(Expr("a=? AND b=':b'", sql2.Named("a", 1)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "a=? AND b=':b'", sql)
+	assert.EqualValues(t, []interface{}{sql2.Named("a", 1)}, args)
+
+	_, _, err = ToSQL(Expr("a=:a AND b=:b", map[string]interface{}{"a": 1}))
+	assert.EqualError(t, err, ErrNoNamedArgument.Error())
+
+	// named placeholders are resolved out of WHERE too
+	n := map[string]interface{}{"n": 1}
+	sql, args, err = Postgres().Insert(Eq{"a": Expr("x + :n", n)}).Into("t").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO t (a) Values ((x + $1))", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, args, err = Postgres().Select("a").From("t").GroupBy(Expr("x + :n", n)).OrderBy(Expr("x + :n", n)).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t GROUP BY x + $1 ORDER BY x + $2", sql)
+	assert.EqualValues(t, []interface{}{1, 1}, args)
+
+	_, _, err = Select("a").From("t").OrderBy(Desc(Expr("x + :n", map[string]interface{}{}))).ToSQL()
+	assert.EqualError(t, err, ErrNoNamedArgument.Error())
+}
+
+func TestBuilder_ToNamedSQL(t *testing.T) {
+	sql, args, err := MsSQL().Select("id").From("table1").
+		Where(Expr("a=:a OR b=:a", map[string]interface{}{"a": 1}).And(Eq{"c": 2}, Eq{"p1": sql2.Named("p1", 3)})).ToNamedSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT id FROM table1 WHERE (a=@a OR b=@a) AND c=@p2 AND p1=@p1", sql)
+	assert.EqualValues(t, []sql2.NamedArg{sql2.Named("a", 1), sql2.Named("p2", 2), sql2.Named("p1", 3)}, args)
+
+	sql, args, err = Oracle().Update(Eq{"a": 1}).From("table1").Where(Expr("b=:b", sql2.Named("b", 2))).ToNamedSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=:p1 WHERE b=:b", sql)
+	assert.EqualValues(t, []sql2.NamedArg{sql2.Named("p1", 1), sql2.Named("b", 2)}, args)
+
+	_, _, err = Select("id").From("table1").
+		Where(Expr("a=:a", map[string]interface{}{"a": 1}).And(Expr("b=:a", map[string]interface{}{"a": 2}))).ToNamedSQL()
+	assert.EqualError(t, err, ErrInconsistentNamedArgument.Error())
+}
+
 func TestBuilder_ToBoundSQL(t *testing.T) {
 	newSQL, err := Select("id").From("table").Where(In("a", 1, 2)).ToBoundSQL()
 	assert.NoError(t, err)
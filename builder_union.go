@@ -9,33 +9,116 @@ import (
 	"strings"
 )
 
+// Intersect sets intersect conditions, intersectTp could be "all", "distinct" or ""
+func (b *Builder) Intersect(intersectTp string, intersectCond *Builder) *Builder {
+	return b.setOperation("INTERSECT", intersectTp, intersectCond)
+}
+
+// Except sets except conditions, exceptTp could be "all", "distinct" or "".
+// It will be written as MINUS in Oracle
+func (b *Builder) Except(exceptTp string, exceptCond *Builder) *Builder {
+	return b.setOperation("EXCEPT", exceptTp, exceptCond)
+}
+
+func (b *Builder) setOperation(op, tp string, cond *Builder) *Builder {
+	var builder *Builder
+	if b.optype != unionType {
+		builder = &Builder{cond: NewCond()}
+		builder.optype = unionType
+		builder.dialect = b.dialect
+		builder.selects = b.selects
+		// CTEs should prefix the whole UNION statement
+		builder.ctes = b.ctes
+		b.ctes = nil
+
+		currentUnions := b.unions
+		// erase sub unions (actually append to new Builder.unions)
+		b.unions = nil
+
+		for e := range currentUnions {
+			currentUnions[e].builder.dialect = b.dialect
+		}
+
+		builder.unions = append(append(builder.unions, union{builder: b}), currentUnions...)
+	} else {
+		builder = b
+	}
+
+	if cond != nil {
+		if cond.dialect == "" && builder.dialect != "" {
+			cond.dialect = builder.dialect
+		}
+
+		builder.unions = append(builder.unions, union{op, tp, cond})
+	}
+
+	return builder
+}
+
+func (u union) operator(dialect string) (string, error) {
+	tp := strings.ToUpper(u.unionType)
+	if u.op != "UNION" && tp == "ALL" && (dialect == MSSQL || dialect == SQLITE) {
+		return "", ErrNotSupportDialectType
+	}
+
+	if u.op == "EXCEPT" && dialect == ORACLE {
+		return "MINUS " + tp, nil
+	}
+	return u.op + " " + tp, nil
+}
+
 func (b *Builder) unionWriteTo(w Writer) error {
-	if b.limitation != nil || b.cond.IsValid() ||
-		b.orderBy != "" || b.having != "" || b.groupBy != "" {
+	if b.cond.IsValid() || (b.having != nil && b.having.IsValid()) || len(b.groupBy) > 0 {
 		return ErrNotUnexpectedUnionConditions
 	}
 
+	// the combined result is paginated through sub-queries in Oracle and MSSQL
+	if b.limitation != nil && b.rewriteLimit() {
+		return b.limitWriteTo(w)
+	}
+
+	// a change of set operator closes the operations before it, so that
+	// operators are always evaluated from left to right
+	for idx := 2; idx < len(b.unions); idx++ {
+		if b.unions[idx].op != b.unions[idx-1].op {
+			fmt.Fprint(w, "(")
+		}
+	}
+
 	for idx, u := range b.unions {
 		current := u.builder
-		if current.optype != selectType {
+		if current.optype != selectType && current.optype != unionType {
 			return ErrUnsupportedUnionMembers
 		}
 
 		if len(b.unions) == 1 {
-			if err := current.selectWriteTo(w); err != nil {
+			if err := current.WriteTo(w); err != nil {
 				return err
 			}
 		} else {
-			if b.dialect != "" && b.dialect != current.dialect {
+			// dialect of union members will inherit from the main one (if not set up)
+			if b.dialect != "" && current.dialect == "" {
+				current.dialect = b.dialect
+			}
+
+			if b.dialect != "" && b.family() != current.family() {
 				return ErrInconsistentDialect
 			}
 
 			if idx != 0 {
-				fmt.Fprint(w, fmt.Sprintf(" UNION %v ", strings.ToUpper(u.unionType)))
+				if idx > 1 && u.op != b.unions[idx-1].op {
+					fmt.Fprint(w, ")")
+				}
+
+				operator, err := u.operator(b.family())
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(w, " ", operator, " ")
 			}
 			fmt.Fprint(w, "(")
 
-			if err := current.selectWriteTo(w); err != nil {
+			if err := current.WriteTo(w); err != nil {
 				return err
 			}
 
@@ -43,5 +126,19 @@ func (b *Builder) unionWriteTo(w Writer) error {
 		}
 	}
 
+	if len(b.orderBy) > 0 {
+		if _, err := fmt.Fprint(w, " ORDER BY "); err != nil {
+			return err
+		}
+
+		if err := b.orderByWriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	if b.limitation != nil {
+		return b.limitWriteTo(w)
+	}
+
 	return nil
 }
@@ -72,3 +72,71 @@ func TestBuilder_Union(t *testing.T) {
 	assert.NoError(t, err)
 	fmt.Println(sql, args)
 }
+
+func TestBuilder_IntersectExcept(t *testing.T) {
+	sql, args, err := Select("a").From("t1").Where(Eq{"b": 1}).
+		Intersect("", Select("a").From("t2").Where(Eq{"b": 2})).
+		Intersect("all", Select("a").From("t3")).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "(SELECT a FROM t1 WHERE b=?) INTERSECT  (SELECT a FROM t2 WHERE b=?) INTERSECT ALL (SELECT a FROM t3)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	// operations before a different operator are enclosed
+	sql, args, err = Select("a").From("t1").
+		Union("all", Select("a").From("t2")).
+		Intersect("", Select("a").From("t3")).
+		Except("", Select("a").From("t4")).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "(((SELECT a FROM t1) UNION ALL (SELECT a FROM t2)) INTERSECT  (SELECT a FROM t3)) EXCEPT  (SELECT a FROM t4)", sql)
+	assert.EqualValues(t, 0, len(args))
+
+	// set operations could be nested
+	sql, args, err = Postgres().Select("a").From("t1").
+		Union("", Select("a").From("t2").Where(Eq{"b": 2}).Intersect("all", Select("a").From("t3").Where(Eq{"b": 3}))).
+		ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "(SELECT a FROM t1) UNION  ((SELECT a FROM t2 WHERE b=$1) INTERSECT ALL (SELECT a FROM t3 WHERE b=$2))", sql)
+	assert.EqualValues(t, []interface{}{2, 3}, args)
+
+	sql, _, err = Oracle().Select("a").From("t1").Except("", Select("a").From("t2")).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "(SELECT a FROM t1) MINUS  (SELECT a FROM t2)", sql)
+
+	_, _, err = MsSQL().Select("a").From("t1").Except("all", Select("a").From("t2")).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = SQLite().Select("a").From("t1").Intersect("all", Select("a").From("t2")).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
+
+func TestBuilder_UnionOrderByLimit(t *testing.T) {
+	sql, args, err := Postgres().Select("a").From("t1").Where(Eq{"b": 1}).
+		Union("all", Select("a").From("t2")).
+		OrderBy(Desc("a")).Limit(5, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "(SELECT a FROM t1 WHERE b=$1) UNION ALL (SELECT a FROM t2) ORDER BY a DESC LIMIT 5 OFFSET 10", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, args, err = Oracle().Select("a").From("t1").Where(Eq{"b": 1}).
+		Except("", Select("a").From("t2")).
+		OrderBy("a").Limit(5, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM (SELECT * FROM (SELECT at.*,ROWNUM RN FROM ((SELECT a FROM t1 WHERE b=:p1) "+
+		"MINUS  (SELECT a FROM t2) ORDER BY a) at) at WHERE at.RN<=:p2) att WHERE att.RN>:p3", sql)
+	assert.EqualValues(t, 3, len(args))
+
+	sql, args, err = MsSQL().Select("a").From("t1").Where(Eq{"b": 1}).
+		Union("all", Select("a").From("t2")).
+		OrderBy("a").Limit(5, 10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT TOP 5 a FROM (SELECT *,ROW_NUMBER() OVER (ORDER BY a) AS RN FROM ((SELECT a FROM t1 WHERE b=@p1) "+
+		"UNION ALL (SELECT a FROM t2)) at) at WHERE at.RN<=@p2 AND at.RN>@p3 ORDER BY at.RN", sql)
+	assert.EqualValues(t, 3, len(args))
+
+	sql, _, err = MsSQL().Select("a").From("t1").Union("all", Select("a").From("t2")).Limit(5).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT TOP 5 a FROM (SELECT *,ROW_NUMBER() OVER (ORDER BY (SELECT 1)) AS RN FROM ((SELECT a FROM t1) "+
+		"UNION ALL (SELECT a FROM t2)) at) at WHERE at.RN<=@p1 ORDER BY at.RN", sql)
+}
@@ -21,26 +21,176 @@ func (b *Builder) updateWriteTo(w Writer) error {
 	if len(b.updates) <= 0 {
 		return ErrNoColumnToUpdate
 	}
+	if b.missingPk {
+		return ErrNoPrimaryKey
+	}
+	if err := b.checkReturning(); err != nil {
+		return err
+	}
 
-	if _, err := fmt.Fprintf(w, "UPDATE %s SET ", b.from); err != nil {
+	if err := b.checkModifyLimit(); err != nil {
 		return err
 	}
 
-	for i, s := range b.updates {
-		if err := s.opWriteTo(",", w); err != nil {
+	if len(b.joins) > 0 {
+		return b.joinedUpdateWriteTo(w)
+	}
+
+	if err := b.checkWhere(); err != nil {
+		return err
+	}
+
+	var target = quoteTable(w, b.from)
+	top, alias := b.orderedTop()
+	if top != nil {
+		target = quoteName(w, alias)
+	}
+
+	if _, err := fmt.Fprint(w, "UPDATE"); err != nil {
+		return err
+	}
+
+	if err := b.topWriteTo(w); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, " %s SET ", target); err != nil {
+		return err
+	}
+
+	if err := writeUpdates(w, b.updates); err != nil {
+		return err
+	}
+
+	if err := b.outputWriteTo(w, "INSERTED"); err != nil {
+		return err
+	}
+
+	var cond = b.limitedCond()
+	if top != nil {
+		if _, err := fmt.Fprint(w, " FROM ("); err != nil {
+			return err
+		}
+		if err := top.WriteTo(w); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, ") ", target); err != nil {
 			return err
 		}
+		cond = NewCond()
+	}
 
-		if i != len(b.updates)-1 {
-			if _, err := fmt.Fprint(w, ","); err != nil {
+	if cond.IsValid() {
+		if _, err := fmt.Fprint(w, " WHERE "); err != nil {
+			return err
+		}
+
+		if err := cond.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	if err := b.modifyLimitWriteTo(w); err != nil {
+		return err
+	}
+
+	return b.returningWriteTo(w)
+}
+
+// joinedUpdateWriteTo writes an UPDATE statement which joins other tables
+func (b *Builder) joinedUpdateWriteTo(w Writer) error {
+	var cond = b.cond
+	switch b.family() {
+	case MYSQL:
+		if _, err := fmt.Fprint(w, "UPDATE ", quoteTable(w, b.from)); err != nil {
+			return err
+		}
+		for _, v := range b.joins {
+			if err := b.joinWriteTo(w, v); err != nil {
 				return err
 			}
 		}
+		if _, err := fmt.Fprint(w, " SET "); err != nil {
+			return err
+		}
+	case MSSQL:
+		if _, err := fmt.Fprintf(w, "UPDATE %s SET ", quoteName(w, tableRef(b.from))); err != nil {
+			return err
+		}
+	case POSTGRES, SQLITE, ORACLE:
+		target := b.from
+		if b.family() == SQLITE {
+			target = aliasAs(target)
+		}
+		if _, err := fmt.Fprintf(w, "UPDATE %s SET ", quoteTable(w, target)); err != nil {
+			return err
+		}
+
+		var err error
+		if cond, err = b.joinedWhere(w); err != nil {
+			return err
+		}
+	case "":
+		return ErrDialectNotSetUp
+	default:
+		return ErrNotSupportDialectType
 	}
 
-	if _, err := fmt.Fprint(w, " WHERE "); err != nil {
+	if err := b.checkWhere(); err != nil {
 		return err
 	}
 
-	return b.cond.WriteTo(w)
+	if err := writeUpdates(w, b.updates); err != nil {
+		return err
+	}
+
+	if err := b.outputWriteTo(w, "INSERTED"); err != nil {
+		return err
+	}
+
+	switch b.family() {
+	case MSSQL:
+		if _, err := fmt.Fprint(w, " FROM ", quoteTable(w, b.from)); err != nil {
+			return err
+		}
+		for _, v := range b.joins {
+			if err := b.joinWriteTo(w, v); err != nil {
+				return err
+			}
+		}
+	case POSTGRES:
+		if _, err := fmt.Fprint(w, " FROM "); err != nil {
+			return err
+		}
+		if err := b.joinTablesWriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	if cond.IsValid() {
+		if _, err := fmt.Fprint(w, " WHERE "); err != nil {
+			return err
+		}
+
+		if err := cond.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	return b.returningWriteTo(w)
+}
+
+func writeUpdates(w Writer, updates []Eq) error {
+	for i, s := range updates {
+		if err := s.opWriteTo(",", w); err != nil {
+			return err
+		}
+
+		if i != len(updates)-1 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
@@ -44,9 +44,112 @@ func TestBuilderUpdate(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualValues(t, ErrNoColumnToUpdate, err)
 
+	// all rows are updated only on purpose
+	_, _, err = Update(Eq{"a": 1}).From("table1").ToSQL()
+	assert.EqualValues(t, ErrNoWhereCondition, err)
+
+	sql, args, err = Update(Eq{"a": 1}).From("table1").All().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=?", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
 	var builder = Builder{cond: NewCond()}
 	sql, args, err = builder.Update(Eq{"a": 2, "b": 1}).From("table1").Where(Eq{"a": 1}).ToSQL()
 	assert.NoError(t, err)
 	assert.EqualValues(t, "UPDATE table1 SET a=?,b=? WHERE a=?", sql)
 	assert.EqualValues(t, []interface{}{2, 1, 1}, args)
 }
+
+func TestBuilderUpdateJoin(t *testing.T) {
+	sql, args, err := MySQL().Update(Eq{"t.a": Expr("u.b")}, Eq{"t.c": 1}).From("table1 t").
+		InnerJoin("table2 u", "u.id=t.id").Where(Eq{"u.d": 2}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 t INNER JOIN table2 u ON u.id=t.id SET t.a=(u.b),t.c=? WHERE u.d=?", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Postgres().Update(Eq{"a": Expr("u.b")}, Eq{"c": 1}).From("table1 t").
+		InnerJoin("table2 u", "u.id=t.id").Where(Eq{"u.d": 2}).Returning("t.id").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 t SET a=(u.b),c=$1 FROM table2 u WHERE (u.id=t.id) AND u.d=$2 RETURNING t.id", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = MsSQL().Update(Eq{"t.a": Expr("u.b")}).From("table1 t").
+		LeftJoin("table2 u", "u.id=t.id").Where(Eq{"u.d": 2}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE t SET t.a=(u.b) FROM table1 t LEFT JOIN table2 u ON u.id=t.id WHERE u.d=@p1", sql)
+	assert.EqualValues(t, 1, len(args))
+
+	// joined tables are checked in a correlated sub-query
+	sql, args, err = SQLite().Update(Eq{"c": 1}).From("table1").
+		InnerJoin("table2 u", Using{"id"}).Where(Eq{"u.d": 2}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET c=? WHERE EXISTS (SELECT 1 FROM table2 u WHERE (table1.id=u.id) AND u.d=?)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	// SQLite requires AS for the alias of the target
+	sql, args, err = SQLite().Update(Eq{"c": 1}).From("table1 t").
+		InnerJoin("table2 u", "u.id=t.id").Where(Eq{"u.d": 2}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 AS t SET c=? WHERE EXISTS (SELECT 1 FROM table2 u WHERE (u.id=t.id) AND u.d=?)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Oracle().Update(Eq{"c": 1}).From("table1 t").
+		InnerJoin("table2 u", "u.id=t.id").InnerJoin(Select("id").From("table3").Where(Gt{"e": 3}).As("v"), "v.id=u.id").All().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 t SET c=:p1 WHERE EXISTS (SELECT 1 FROM table2 u, (SELECT id FROM table3 WHERE e>:p2) v "+
+		"WHERE (u.id=t.id) AND (v.id=u.id))", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	// joins are not conditions, they may match every row
+	_, _, err = MySQL().Update(Eq{"c": 1}).From("table1 t").InnerJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.EqualValues(t, ErrNoWhereCondition, err)
+
+	_, _, err = Postgres().Update(Eq{"c": 1}).From("table1 t").LeftJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = Update(Eq{"c": 1}).From("table1 t").InnerJoin("table2 u", "u.id=t.id").ToSQL()
+	assert.EqualValues(t, ErrDialectNotSetUp, err)
+}
+
+func TestBuilderUpdateLimit(t *testing.T) {
+	sql, args, err := MySQL().Update(Eq{"a": 1}).From("table1").Where(Eq{"b": 2}).OrderBy("id").Limit(10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=? WHERE b=? ORDER BY id LIMIT 10", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Postgres().Update(Eq{"a": 1}).From("table1").Where(Eq{"b": 2}).OrderBy("id").Limit(10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=$1 WHERE ctid IN (SELECT ctid FROM table1 WHERE b=$2 ORDER BY id LIMIT 10)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = SQLite().Update(Eq{"a": 1}).From("table1").Where(Eq{"b": 2}).OrderBy("id").Limit(10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=? WHERE rowid IN (SELECT rowid FROM table1 WHERE b=? ORDER BY id LIMIT 10)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = MsSQL().Update(Eq{"a": 1}).From("table1").Where(Eq{"b": 2}).Limit(10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE TOP (10) table1 SET a=@p1 WHERE b=@p2", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	// TOP ignores ORDER BY in UPDATE of MSSQL, update the ordered rows in a derived table instead
+	sql, args, err = MsSQL().Update(Eq{"a": 1}).From("dbo.table1").Where(Eq{"b": 2}).OrderBy("id").Limit(10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=@p1 FROM (SELECT TOP (10) * FROM dbo.table1 WHERE b=@p2 ORDER BY id) table1", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	sql, args, err = Oracle().Update(Eq{"a": 1}).From("table1").Where(Eq{"b": 2}).OrderBy("id").Limit(10).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "UPDATE table1 SET a=:p1 WHERE ROWID IN (SELECT rid FROM (SELECT ROWID rid FROM table1 WHERE b=:p2 ORDER BY id) at "+
+		"WHERE ROWNUM<=:p3)", sql)
+	assert.EqualValues(t, 3, len(args))
+
+	_, _, err = MySQL().Update(Eq{"a": 1}).From("table1").Limit(10, 5).ToSQL()
+	assert.EqualValues(t, ErrInvalidLimitation, err)
+
+	_, _, err = Update(Eq{"a": 1}).From("table1").Limit(10).ToSQL()
+	assert.EqualValues(t, ErrDialectNotSetUp, err)
+
+	_, _, err = MySQL().Update(Eq{"a": 1}).From("table1 t").InnerJoin("table2 u", "u.id=t.id").Limit(10).ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+}
@@ -0,0 +1,239 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Upsert turns an insert Builder into an "insert or update" statement. conflictCols are the
+// columns of the unique key which may conflict, updates are applied to the existing row when
+// a conflict happens. If no updates are given, the conflicting row is left untouched.
+func (b *Builder) Upsert(conflictCols []string, updates ...Eq) *Builder {
+	b.upsert = &upsert{conflictCols: conflictCols}
+	for _, update := range updates {
+		if update.IsValid() {
+			b.upsert.updates = append(b.upsert.updates, update)
+		}
+	}
+	return b
+}
+
+// qualifiedUpdates returns the upsert updates with Incr and Decr rewritten to reference
+// the column of the target table explicitly, so they can't be mixed up with the inserted values.
+// table should be the alias of the target if it has one
+func (upsert *upsert) qualifiedUpdates(w Writer, table string) []Eq {
+	var updates = make([]Eq, 0, len(upsert.updates))
+	for _, update := range upsert.updates {
+		var eq = make(Eq, len(update))
+		for k, v := range update {
+			switch t := v.(type) {
+			case Incr:
+				eq[k] = Expr(fmt.Sprintf("%s.%s+?", quoteName(w, table), quoteName(w, k)), int(t))
+			case Decr:
+				eq[k] = Expr(fmt.Sprintf("%s.%s-?", quoteName(w, table), quoteName(w, k)), int(t))
+			default:
+				eq[k] = v
+			}
+		}
+		updates = append(updates, eq)
+	}
+	return updates
+}
+
+func (b *Builder) upsertWriteTo(w Writer) error {
+	switch b.family() {
+	case POSTGRES, SQLITE:
+		if err := b.insertValuesWriteTo(w); err != nil {
+			return err
+		}
+
+		if len(b.upsert.updates) == 0 {
+			var err error
+			if len(b.upsert.conflictCols) == 0 {
+				_, err = fmt.Fprint(w, " ON CONFLICT DO NOTHING")
+			} else {
+				_, err = fmt.Fprintf(w, " ON CONFLICT (%s) DO NOTHING", quoteNames(w, b.upsert.conflictCols))
+			}
+			if err != nil {
+				return err
+			}
+			return b.returningWriteTo(w)
+		}
+
+		if len(b.upsert.conflictCols) == 0 {
+			return ErrNoConflictTarget
+		}
+
+		if _, err := fmt.Fprintf(w, " ON CONFLICT (%s) DO UPDATE SET ", quoteNames(w, b.upsert.conflictCols)); err != nil {
+			return err
+		}
+
+		if err := writeUpdates(w, b.upsert.qualifiedUpdates(w, tableRef(b.into))); err != nil {
+			return err
+		}
+
+		return b.returningWriteTo(w)
+	case MYSQL:
+		if err := b.insertValuesWriteTo(w); err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprint(w, " ON DUPLICATE KEY UPDATE "); err != nil {
+			return err
+		}
+
+		if len(b.upsert.updates) == 0 {
+			// a no-op assignment keeps the existing row as it is
+			col := b.insertCols[0]
+			if len(b.upsert.conflictCols) > 0 {
+				col = b.upsert.conflictCols[0]
+			}
+			_, err := fmt.Fprintf(w, "%s=%s", quoteName(w, col), quoteName(w, col))
+			return err
+		}
+
+		return writeUpdates(w, b.upsert.updates)
+	case MSSQL:
+		return b.mergeWriteTo(w)
+	case ORACLE:
+		// RETURNING INTO can't be used with MERGE
+		if b.returning != nil {
+			return ErrNotSupportDialectType
+		}
+		return b.mergeWriteTo(w)
+	case "":
+		return ErrDialectNotSetUp
+	}
+
+	return ErrNotSupportDialectType
+}
+
+// mergeWriteTo writes the upsert as a MERGE statement which is the only way
+// to express it on MSSQL and Oracle
+func (b *Builder) mergeWriteTo(w Writer) error {
+	if len(b.upsert.conflictCols) == 0 {
+		return ErrNoConflictTarget
+	}
+
+	if _, err := fmt.Fprintf(w, "MERGE INTO %s USING (", quoteTable(w, b.into)); err != nil {
+		return err
+	}
+
+	for i, row := range b.insertRows() {
+		if len(row) != len(b.insertCols) {
+			return ErrInconsistentInsertRows
+		}
+
+		if i > 0 {
+			if _, err := fmt.Fprint(w, " UNION ALL "); err != nil {
+				return err
+			}
+		}
+
+		if err := b.mergeSourceWriteTo(w, row); err != nil {
+			return err
+		}
+	}
+
+	// Oracle doesn't accept AS before a table alias
+	if b.family() == ORACLE {
+		if _, err := fmt.Fprint(w, ") src ON ("); err != nil {
+			return err
+		}
+	} else {
+		if _, err := fmt.Fprint(w, ") AS src ON ("); err != nil {
+			return err
+		}
+	}
+
+	for i, col := range b.upsert.conflictCols {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, " AND "); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s.%s=src.%s", quoteName(w, tableRef(b.into)), quoteName(w, col), quoteName(w, col)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprint(w, ")"); err != nil {
+		return err
+	}
+
+	if len(b.upsert.updates) > 0 {
+		if _, err := fmt.Fprint(w, " WHEN MATCHED THEN UPDATE SET "); err != nil {
+			return err
+		}
+		if err := writeUpdates(w, b.upsert.qualifiedUpdates(w, tableRef(b.into))); err != nil {
+			return err
+		}
+	}
+
+	var srcCols = make([]string, 0, len(b.insertCols))
+	for _, col := range b.insertCols {
+		srcCols = append(srcCols, "src."+quoteName(w, col))
+	}
+	if _, err := fmt.Fprintf(w, " WHEN NOT MATCHED THEN INSERT (%s) Values (%s)",
+		quoteNames(w, b.insertCols), strings.Join(srcCols, ",")); err != nil {
+		return err
+	}
+
+	if err := b.outputWriteTo(w, "INSERTED"); err != nil {
+		return err
+	}
+
+	// MERGE statement must be terminated by a semicolon in MSSQL
+	if b.family() == MSSQL {
+		if _, err := fmt.Fprint(w, ";"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeSourceWriteTo writes a row to insert as a SELECT which is used as the source of MERGE
+func (b *Builder) mergeSourceWriteTo(w Writer, row []interface{}) error {
+	if _, err := fmt.Fprint(w, "SELECT "); err != nil {
+		return err
+	}
+
+	for i, col := range b.insertCols {
+		if e, ok := row[i].(expr); ok {
+			if _, err := fmt.Fprint(w, "("); err != nil {
+				return err
+			}
+			if err := e.WriteTo(w); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, ") AS %s", quoteName(w, col)); err != nil {
+				return err
+			}
+		} else {
+			if _, err := fmt.Fprintf(w, "? AS %s", quoteName(w, col)); err != nil {
+				return err
+			}
+			w.Append(row[i])
+		}
+
+		if i != len(b.insertCols)-1 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Oracle needs a FROM clause in every SELECT
+	if b.family() == ORACLE {
+		if _, err := fmt.Fprint(w, " FROM DUAL"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderUpsert(t *testing.T) {
+	sql, args, err := Postgres().Insert(Eq{"id": 1, "name": "a", "cnt": 1}).Into("table1").
+		Upsert([]string{"id"}, Eq{"name": "a", "cnt": Incr(1)}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (cnt,id,name) Values ($1,$2,$3) ON CONFLICT (id) DO UPDATE SET cnt=(table1.cnt+$4),name=$5", sql)
+	assert.EqualValues(t, []interface{}{1, 1, "a", 1, "a"}, args)
+
+	// the columns are qualified by the alias of the target
+	sql, args, err = Postgres().Insert(Eq{"id": 1, "cnt": 1}).Into("table1 t").
+		Upsert([]string{"id"}, Eq{"cnt": Incr(2)}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 AS t (cnt,id) Values ($1,$2) ON CONFLICT (id) DO UPDATE SET cnt=(t.cnt+$3)", sql)
+	assert.EqualValues(t, []interface{}{1, 1, 2}, args)
+
+	sql, _, err = MsSQL().Insert(Eq{"id": 1, "cnt": 1}).Into("table1 t").
+		Upsert([]string{"id"}, Eq{"cnt": Decr(2)}).QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "MERGE INTO [table1] [t] USING (SELECT @p1 AS [cnt],@p2 AS [id]) AS src ON ([t].[id]=src.[id]) "+
+		"WHEN MATCHED THEN UPDATE SET [cnt]=([t].[cnt]-@p3) WHEN NOT MATCHED THEN INSERT ([cnt],[id]) Values (src.[cnt],src.[id]);", sql)
+
+	sql, args, err = SQLite().Insert(Eq{"id": 1, "name": "a"}).Into("table1").
+		Upsert([]string{"id"}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (id,name) Values (?,?) ON CONFLICT (id) DO NOTHING", sql)
+	assert.EqualValues(t, []interface{}{1, "a"}, args)
+
+	sql, args, err = MySQL().Insert(Eq{"id": 1, "name": "a"}).Into("table1").
+		Upsert([]string{"id"}, Eq{"name": Expr("CONCAT(name, ?)", "b")}, Eq{"cnt": Decr(1)}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (id,name) Values (?,?) ON DUPLICATE KEY UPDATE name=(CONCAT(name, ?)),cnt=cnt-?", sql)
+	assert.EqualValues(t, []interface{}{1, "a", "b", 1}, args)
+
+	sql, args, err = MySQL().Insert(Eq{"id": 1, "name": "a"}).Into("table1").
+		Upsert(nil).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table1 (id,name) Values (?,?) ON DUPLICATE KEY UPDATE id=id", sql)
+	assert.EqualValues(t, []interface{}{1, "a"}, args)
+
+	sql, err = MsSQL().Insert(Eq{"id": 1, "name": "a", "cnt": 1}).Into("table1").
+		Upsert([]string{"id"}, Eq{"name": "a", "cnt": Incr(1)}).ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "MERGE INTO table1 USING (SELECT 1 AS cnt,1 AS id,N'a' AS name) AS src ON (table1.id=src.id) "+
+		"WHEN MATCHED THEN UPDATE SET cnt=(table1.cnt+1),name=N'a' "+
+		"WHEN NOT MATCHED THEN INSERT (cnt,id,name) Values (src.cnt,src.id,src.name);", sql)
+
+	sql, args, err = Oracle().Insert(Eq{"id": 1, "name": "a"}).Into("table1").
+		Upsert([]string{"id"}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "MERGE INTO table1 USING (SELECT :p1 AS id,:p2 AS name FROM DUAL) src ON (table1.id=src.id) "+
+		"WHEN NOT MATCHED THEN INSERT (id,name) Values (src.id,src.name)", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	_, _, err = Postgres().Insert(Eq{"id": 1, "name": "a"}).Into("table1").
+		Upsert(nil, Eq{"name": "a"}).ToSQL()
+	assert.EqualValues(t, ErrNoConflictTarget, err)
+
+	_, _, err = Oracle().Insert(Eq{"id": 1, "name": "a"}).Into("table1").
+		Upsert(nil, Eq{"name": "a"}).ToSQL()
+	assert.EqualValues(t, ErrNoConflictTarget, err)
+
+	_, _, err = Insert(Eq{"id": 1, "name": "a"}).Into("table1").
+		Upsert([]string{"id"}, Eq{"name": "a"}).ToSQL()
+	assert.EqualValues(t, ErrDialectNotSetUp, err)
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+)
+
+type cte struct {
+	name      string
+	cols      []string
+	recursive bool
+	builder   *Builder
+}
+
+// With creates a Builder with a common table expression
+func With(name string, builder *Builder) *Builder {
+	return (&Builder{cond: NewCond()}).With(name, builder)
+}
+
+// WithRecursive creates a Builder with a recursive common table expression
+func WithRecursive(name string, cols []string, builder *Builder) *Builder {
+	return (&Builder{cond: NewCond()}).WithRecursive(name, cols, builder)
+}
+
+// With adds a common table expression which prefixes the statement
+func (b *Builder) With(name string, builder *Builder) *Builder {
+	b.ctes = append(b.ctes, cte{name: name, builder: builder})
+	return b
+}
+
+// WithRecursive adds a recursive common table expression which prefixes the statement,
+// cols are the column names of the CTE which are required by some dialects
+func (b *Builder) WithRecursive(name string, cols []string, builder *Builder) *Builder {
+	b.ctes = append(b.ctes, cte{name: name, cols: cols, recursive: true, builder: builder})
+	return b
+}
+
+func (b *Builder) withWriteTo(w Writer) error {
+	// Oracle doesn't support WITH clause in UPDATE and DELETE
+	if b.family() == ORACLE && (b.optype == updateType || b.optype == deleteType) {
+		return ErrNotSupportDialectType
+	}
+
+	var recursive bool
+	for _, c := range b.ctes {
+		if c.recursive {
+			recursive = true
+			break
+		}
+	}
+
+	// MSSQL and Oracle don't need the RECURSIVE keyword
+	if recursive && b.family() != MSSQL && b.family() != ORACLE {
+		if _, err := fmt.Fprint(w, "WITH RECURSIVE "); err != nil {
+			return err
+		}
+	} else {
+		if _, err := fmt.Fprint(w, "WITH "); err != nil {
+			return err
+		}
+	}
+
+	for i, c := range b.ctes {
+		if c.builder == nil {
+			return ErrUnexpectedSubQuery
+		}
+
+		if c.builder.dialect != "" && b.family() != c.builder.family() {
+			return ErrInconsistentDialect
+		}
+
+		// dialect of CTE will inherit from the main one (if not set up)
+		if b.dialect != "" && c.builder.dialect == "" {
+			c.builder.dialect = b.dialect
+		}
+
+		switch c.builder.optype {
+		case selectType, unionType:
+		case insertType, updateType, deleteType:
+			// only Postgres supports data-modifying statements in WITH
+			if b.family() != POSTGRES {
+				return ErrUnexpectedSubQuery
+			}
+		default:
+			return ErrUnexpectedSubQuery
+		}
+
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+
+		if len(c.cols) > 0 {
+			if _, err := fmt.Fprintf(w, "%s (%s) AS (", quoteName(w, c.name), quoteNames(w, c.cols)); err != nil {
+				return err
+			}
+		} else {
+			if _, err := fmt.Fprintf(w, "%s AS (", quoteName(w, c.name)); err != nil {
+				return err
+			}
+		}
+
+		if err := c.builder.WriteTo(w); err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprint(w, ")"); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprint(w, " ")
+	return err
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_With(t *testing.T) {
+	sql, args, err := With("t", Select("id").From("table1").Where(Eq{"a": 1})).
+		Select("id").From("t").Where(Eq{"b": 2}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH t AS (SELECT id FROM table1 WHERE a=?) SELECT id FROM t WHERE b=?", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = Postgres().WithRecursive("tree", []string{"id", "parent_id"},
+		Select("id", "parent_id").From("nodes").Where(Eq{"id": 1}).
+			Union("all", Select("n.id", "n.parent_id").From("nodes n").InnerJoin("tree", "n.parent_id = tree.id"))).
+		With("t2", Select("id").From("table2").Where(Eq{"a": 2})).
+		Select("*").From("tree").Where(Eq{"id": Select("id").From("t2")}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH RECURSIVE tree (id,parent_id) AS ((SELECT id,parent_id FROM nodes WHERE id=$1) UNION ALL "+
+		"(SELECT n.id,n.parent_id FROM nodes n INNER JOIN tree ON n.parent_id = tree.id)),t2 AS (SELECT id FROM table2 WHERE a=$2) "+
+		"SELECT * FROM tree WHERE id=(SELECT id FROM t2)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	// RECURSIVE keyword is not used in MSSQL and the limit is applied to the main query only
+	sql, err = MsSQL().WithRecursive("t", []string{"n"}, Select("n").From("seed")).
+		Select("n").From("t").Limit(5).ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH t (n) AS (SELECT n FROM seed) SELECT TOP 5 n FROM (SELECT TOP 5 n,ROW_NUMBER() OVER (ORDER BY (SELECT 1)) AS RN FROM t) at ORDER BY at.RN", sql)
+
+	sql, args, err = With("t", Select("id").From("table1").Where(Eq{"a": 1})).
+		Update(Eq{"b": 2}).From("table2").Where(In("id", Select("id").From("t"))).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH t AS (SELECT id FROM table1 WHERE a=?) UPDATE table2 SET b=? WHERE id IN (SELECT id FROM t)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	sql, args, err = With("t", Select("id").From("table1").Where(Eq{"a": 1})).
+		Delete(In("id", Select("id").From("t"))).From("table2").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH t AS (SELECT id FROM table1 WHERE a=?) DELETE FROM table2 WHERE id IN (SELECT id FROM t)", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	// MySQL puts WITH in front of the SELECT part of INSERT
+	sql, args, err = MySQL().With("t", Select("id").From("table1").Where(Eq{"a": 1})).
+		Insert("id").Into("table2").Select("id").From("t").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO table2 (id) WITH t AS (SELECT id FROM table1 WHERE a=?) SELECT id FROM t", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, args, err = Postgres().With("t", Select("id").From("table1").Where(Eq{"a": 1})).
+		Insert("id").Into("table2").Select("id").From("t").ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH t AS (SELECT id FROM table1 WHERE a=$1) INSERT INTO table2 (id) SELECT id FROM t", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	// CTE prefixes the whole UNION
+	sql, args, err = With("t", Select("id").From("table1")).Select("id").From("t").
+		Union("all", Select("id").From("t")).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "WITH t AS (SELECT id FROM table1) (SELECT id FROM t) UNION ALL (SELECT id FROM t)", sql)
+
+	_, _, err = MySQL().With("t", Select("id").From("table1")).Insert(Eq{"a": 1}).Into("table2").ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = Oracle().With("t", Select("id").From("table1")).Delete(Eq{"a": 1}).From("table2").ToSQL()
+	assert.EqualValues(t, ErrNotSupportDialectType, err)
+
+	_, _, err = MySQL().With("t", Oracle().Select("id").From("table1")).Select("id").From("t").ToSQL()
+	assert.EqualValues(t, ErrInconsistentDialect, err)
+
+	_, _, err = MySQL().With("t", Delete(Eq{"a": 1}).From("table1")).Select("id").From("t").ToSQL()
+	assert.EqualValues(t, ErrUnexpectedSubQuery, err)
+}
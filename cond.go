@@ -5,6 +5,7 @@
 package builder
 
 import (
+	sql2 "database/sql"
 	"io"
 )
 
@@ -20,6 +21,15 @@ var _ Writer = NewWriter()
 type BytesWriter struct {
 	writer *StringBuilder
 	args   []interface{}
+	// dialect of the builder being written, nested builders inherit it
+	dialect string
+	// whether identifiers should be quoted
+	quoted bool
+	// names of the args bound to named placeholders, by their indexes
+	names map[int]string
+	// the registered dialect of dialectName, which is looked up once by writerSQLDialect
+	sqlDialect  SQLDialect
+	dialectName string
 }
 
 // NewWriter creates a new string writer
@@ -35,11 +45,44 @@ func (s *BytesWriter) Write(buf []byte) (int, error) {
 	return s.writer.Write(buf)
 }
 
+// WriteString writes a string to Writer
+func (s *BytesWriter) WriteString(str string) (int, error) {
+	return s.writer.WriteString(str)
+}
+
 // Append appends args to Writer
 func (s *BytesWriter) Append(args ...interface{}) {
 	s.args = append(s.args, args...)
 }
 
+// appendNamed appends an arg bound to a named placeholder
+func (s *BytesWriter) appendNamed(name string, arg interface{}) {
+	if s.names == nil {
+		s.names = make(map[int]string)
+	}
+	s.names[len(s.args)] = name
+	s.args = append(s.args, arg)
+}
+
+// argName returns the name of the i-th arg if it's bound to a named placeholder or a sql.NamedArg
+func (s *BytesWriter) argName(i int) string {
+	if name, ok := s.names[i]; ok {
+		return name
+	}
+	if namedArg, ok := s.args[i].(sql2.NamedArg); ok {
+		return namedArg.Name
+	}
+	return ""
+}
+
+// writerDialect returns the dialect of the builder which is writing to w
+func writerDialect(w Writer) string {
+	if bw, ok := w.(*BytesWriter); ok {
+		return bw.dialect
+	}
+	return ""
+}
+
 // Cond defines an interface
 type Cond interface {
 	WriteTo(Writer) error
@@ -72,3 +115,16 @@ func (condEmpty) Or(conds ...Cond) Cond {
 func (condEmpty) IsValid() bool {
 	return false
 }
+
+// writerSQLDialect returns the registered dialect of the builder which is writing to w, a
+// BytesWriter looks it up once for the dialect being written
+func writerSQLDialect(w Writer) SQLDialect {
+	bw, ok := w.(*BytesWriter)
+	if !ok || bw.dialect == "" {
+		return nil
+	}
+	if bw.dialectName != bw.dialect {
+		bw.sqlDialect, bw.dialectName = LookupDialect(bw.dialect), bw.dialect
+	}
+	return bw.sqlDialect
+}
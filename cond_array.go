@@ -0,0 +1,60 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import "fmt"
+
+type condArray struct {
+	col   string
+	array interface{}
+	not   bool
+}
+
+var _ Cond = condArray{}
+
+// InArray generates a condition matching any value of an array bound as a single argument, which
+// is col=ANY(?) in Postgres and keeps the statement short for huge lists. The array should be a
+// slice, or a value accepted by the driver as an array (e.g. pq.Array) if it's Postgres only.
+// Other databases fall back to IN.
+func InArray(col string, array interface{}) Cond {
+	return condArray{col: col, array: array}
+}
+
+// NotInArray generates a condition matching none of the values of an array, which is
+// col<>ALL(?) in Postgres and NOT IN in other databases
+func NotInArray(col string, array interface{}) Cond {
+	return condArray{col: col, array: array, not: true}
+}
+
+func (condArray condArray) WriteTo(w Writer) error {
+	if dialectFamily(writerDialect(w)) != POSTGRES {
+		if condArray.not {
+			return NotIn(condArray.col, condArray.array).WriteTo(w)
+		}
+		return In(condArray.col, condArray.array).WriteTo(w)
+	}
+
+	op := "=ANY"
+	if condArray.not {
+		op = "<>ALL"
+	}
+	if _, err := fmt.Fprintf(w, "%s%s(?)", quoteName(w, condArray.col), op); err != nil {
+		return err
+	}
+	w.Append(condArray.array)
+	return nil
+}
+
+func (condArray condArray) And(conds ...Cond) Cond {
+	return And(condArray, And(conds...))
+}
+
+func (condArray condArray) Or(conds ...Cond) Cond {
+	return Or(condArray, Or(conds...))
+}
+
+func (condArray condArray) IsValid() bool {
+	return len(condArray.col) > 0 && condArray.array != nil
+}
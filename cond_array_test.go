@@ -0,0 +1,27 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCond_InArray(t *testing.T) {
+	ids := []int64{1, 2, 3}
+
+	sql, args, err := Postgres().Select("a").From("t").Where(InArray("id", ids).And(NotInArray("b", []string{"x"}))).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE id=ANY($1) AND b<>ALL($2)", sql)
+	assert.EqualValues(t, []interface{}{ids, []string{"x"}}, args)
+
+	sql, args, err = MySQL().Select("a").From("t").Where(InArray("id", ids).And(NotInArray("b", []string{"x"}))).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE id IN (?,?,?) AND b NOT IN (?)", sql)
+	assert.EqualValues(t, []interface{}{int64(1), int64(2), int64(3), "x"}, args)
+
+	assert.False(t, InArray("id", nil).IsValid())
+}
@@ -17,7 +17,7 @@ var _ Cond = Between{}
 
 // WriteTo write data to Writer
 func (between Between) WriteTo(w Writer) error {
-	if _, err := fmt.Fprintf(w, "%s BETWEEN ", between.Col); err != nil {
+	if _, err := fmt.Fprintf(w, "%s BETWEEN ", quoteName(w, between.Col)); err != nil {
 		return err
 	}
 	if lv, ok := between.LessVal.(expr); ok {
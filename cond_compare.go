@@ -19,7 +19,7 @@ func WriteMap(w Writer, data map[string]interface{}, op string) error {
 		v := data[k]
 		switch v.(type) {
 		case expr:
-			if _, err := fmt.Fprintf(w, "%s%s(", k, op); err != nil {
+			if _, err := fmt.Fprintf(w, "%s%s(", quoteName(w, k), op); err != nil {
 				return err
 			}
 
@@ -31,7 +31,7 @@ func WriteMap(w Writer, data map[string]interface{}, op string) error {
 				return err
 			}
 		case *Builder:
-			if _, err := fmt.Fprintf(w, "%s%s(", k, op); err != nil {
+			if _, err := fmt.Fprintf(w, "%s%s(", quoteName(w, k), op); err != nil {
 				return err
 			}
 
@@ -43,7 +43,7 @@ func WriteMap(w Writer, data map[string]interface{}, op string) error {
 				return err
 			}
 		default:
-			if _, err := fmt.Fprintf(w, "%s%s?", k, op); err != nil {
+			if _, err := fmt.Fprintf(w, "%s%s?", quoteName(w, k), op); err != nil {
 				return err
 			}
 			args = append(args, v)
@@ -30,7 +30,7 @@ func (eq Eq) opWriteTo(op string, w Writer) error {
 				return err
 			}
 		case expr:
-			if _, err := fmt.Fprintf(w, "%s=(", k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s=(", quoteName(w, k)); err != nil {
 				return err
 			}
 
@@ -42,7 +42,7 @@ func (eq Eq) opWriteTo(op string, w Writer) error {
 				return err
 			}
 		case *Builder:
-			if _, err := fmt.Fprintf(w, "%s=(", k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s=(", quoteName(w, k)); err != nil {
 				return err
 			}
 
@@ -54,17 +54,17 @@ func (eq Eq) opWriteTo(op string, w Writer) error {
 				return err
 			}
 		case Incr:
-			if _, err := fmt.Fprintf(w, "%s=%s+?", k, k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s=%s+?", quoteName(w, k), quoteName(w, k)); err != nil {
 				return err
 			}
 			w.Append(int(v.(Incr)))
 		case Decr:
-			if _, err := fmt.Fprintf(w, "%s=%s-?", k, k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s=%s-?", quoteName(w, k), quoteName(w, k)); err != nil {
 				return err
 			}
 			w.Append(int(v.(Decr)))
 		default:
-			if _, err := fmt.Fprintf(w, "%s=?", k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s=?", quoteName(w, k)); err != nil {
 				return err
 			}
 			w.Append(v)
@@ -0,0 +1,72 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import "fmt"
+
+type condExists struct {
+	not     bool
+	builder *Builder
+}
+
+var _ Cond = condExists{}
+
+// Exists generates EXISTS condition with a sub-query
+func Exists(b *Builder) Cond {
+	return condExists{builder: b}
+}
+
+// NotExists generates NOT EXISTS condition with a sub-query
+func NotExists(b *Builder) Cond {
+	return condExists{not: true, builder: b}
+}
+
+// WriteTo writes SQL to Writer
+func (condExists condExists) WriteTo(w Writer) error {
+	bd := condExists.builder
+	switch bd.optype {
+	case selectType, unionType:
+	default:
+		return ErrUnexpectedSubQuery
+	}
+
+	// dialect of sub-query will inherit from the main one (if not set up)
+	if dialect := writerDialect(w); dialect != "" {
+		if bd.dialect == "" {
+			bd.dialect = dialect
+		} else if bd.family() != dialectFamily(dialect) {
+			return ErrInconsistentDialect
+		}
+	}
+
+	if condExists.not {
+		if _, err := fmt.Fprint(w, "NOT "); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "EXISTS ("); err != nil {
+		return err
+	}
+	if err := bd.WriteTo(w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprint(w, ")")
+	return err
+}
+
+// And implements And with other conditions
+func (condExists condExists) And(conds ...Cond) Cond {
+	return And(condExists, And(conds...))
+}
+
+// Or implements Or with other conditions
+func (condExists condExists) Or(conds ...Cond) Cond {
+	return Or(condExists, Or(conds...))
+}
+
+// IsValid tests if this condition is valid
+func (condExists condExists) IsValid() bool {
+	return condExists.builder != nil
+}
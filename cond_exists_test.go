@@ -0,0 +1,43 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExists(t *testing.T) {
+	sql, args, err := ToSQL(Exists(Select("1").From("table2").Where(Expr("table2.id=table1.id").And(Eq{"b": 2}))))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "EXISTS (SELECT 1 FROM table2 WHERE (table2.id=table1.id) AND b=?)", sql)
+	assert.EqualValues(t, []interface{}{2}, args)
+
+	sql, args, err = Select("a").From("table1").
+		Where(Eq{"a": 1}.And(NotExists(Select("1").From("table2").Where(Expr("table2.id=table1.id"))))).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 WHERE a=? AND NOT EXISTS (SELECT 1 FROM table2 WHERE table2.id=table1.id)", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, args, err = Postgres().Select("a").From("table1").
+		Where(Or(Eq{"a": 1}, Not{Exists(Select("1").From("table2").Where(Eq{"b": 2}))})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 WHERE a=$1 OR NOT EXISTS (SELECT 1 FROM table2 WHERE b=$2)", sql)
+	assert.EqualValues(t, []interface{}{1, 2}, args)
+
+	// dialect of sub-query will inherit from the main one
+	sql, args, err = Oracle().Select("a").From("table1").
+		Where(Exists(Select("1").From("table2").Where(Eq{"b": 2}).Limit(1))).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM table1 WHERE EXISTS (SELECT 1 FROM (SELECT 1,ROWNUM RN FROM table2 WHERE b=:p1) at WHERE at.RN<=:p2)", sql)
+	assert.EqualValues(t, 2, len(args))
+
+	_, _, err = MySQL().Select("a").From("table1").Where(Exists(Oracle().Select("1").From("table2"))).ToSQL()
+	assert.EqualValues(t, ErrInconsistentDialect, err)
+
+	_, _, err = Select("a").From("table1").Where(Exists(Delete().From("table2"))).ToSQL()
+	assert.EqualValues(t, ErrUnexpectedSubQuery, err)
+}
@@ -4,7 +4,14 @@
 
 package builder
 
-import "fmt"
+import (
+	sql2 "database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
 
 type expr struct {
 	sql  string
@@ -13,12 +20,17 @@ type expr struct {
 
 var _ Cond = expr{}
 
-// Expr generate customerize SQL
+// Expr generate customerize SQL. Named placeholders like :name or @name could be used
+// instead of ?, then args should be a map, a struct or some sql.NamedArg
 func Expr(sql string, args ...interface{}) Cond {
 	return expr{sql, args}
 }
 
 func (expr expr) WriteTo(w Writer) error {
+	if sql, names, lookup, ok := expr.namedSQL(dialectFamily(writerDialect(w))); ok {
+		return expr.namedWriteTo(w, sql, names, lookup)
+	}
+
 	if _, err := fmt.Fprint(w, expr.sql); err != nil {
 		return err
 	}
@@ -26,6 +38,54 @@ func (expr expr) WriteTo(w Writer) error {
 	return nil
 }
 
+// namedSQL returns the SQL whose named placeholders have been replaced to ?, their names and
+// the lookup of their values. ok is false if the expression uses positional placeholders
+func (expr expr) namedSQL(family string) (sql string, names []string, lookup func(string) (interface{}, bool), ok bool) {
+	if lookup = namedLookup(expr.args); lookup == nil {
+		return "", nil, nil, false
+	}
+	sql, names, positional := namedPlaceholders(expr.sql, family)
+	if positional || len(names) == 0 {
+		return "", nil, nil, false
+	}
+	return sql, names, lookup, true
+}
+
+// argsLen returns the number of args which are bound when the expression is written
+func (expr expr) argsLen(family string) int {
+	if _, names, _, ok := expr.namedSQL(family); ok {
+		return len(names)
+	}
+	return len(expr.args)
+}
+
+// namedWriteTo writes the SQL whose named placeholders have been replaced to ?, and
+// appends the values of the names
+func (expr expr) namedWriteTo(w Writer, sql string, names []string, lookup func(string) (interface{}, bool)) error {
+	var args = make([]interface{}, 0, len(names))
+	for _, name := range names {
+		arg, ok := lookup(name)
+		if !ok {
+			return ErrNoNamedArgument
+		}
+		args = append(args, arg)
+	}
+
+	if _, err := fmt.Fprint(w, sql); err != nil {
+		return err
+	}
+
+	bw, ok := w.(*BytesWriter)
+	for i, arg := range args {
+		if ok {
+			bw.appendNamed(names[i], arg)
+		} else {
+			w.Append(arg)
+		}
+	}
+	return nil
+}
+
 func (expr expr) And(conds ...Cond) Cond {
 	return And(expr, And(conds...))
 }
@@ -37,3 +97,61 @@ func (expr expr) Or(conds ...Cond) Cond {
 func (expr expr) IsValid() bool {
 	return len(expr.sql) > 0
 }
+
+// namedLookup returns a function to find the values of named placeholders in args, which
+// could be a map with string keys, a struct or some sql.NamedArg. It's nil for other args
+func namedLookup(args []interface{}) func(string) (interface{}, bool) {
+	if len(args) == 0 {
+		return nil
+	}
+
+	if _, ok := args[0].(sql2.NamedArg); ok {
+		var values = make(map[string]interface{}, len(args))
+		for _, arg := range args {
+			namedArg, ok := arg.(sql2.NamedArg)
+			if !ok {
+				return nil
+			}
+			values[namedArg.Name] = namedArg.Value
+		}
+		return func(name string) (interface{}, bool) {
+			v, ok := values[name]
+			return v, ok
+		}
+	}
+
+	if len(args) > 1 {
+		return nil
+	}
+
+	switch args[0].(type) {
+	case driver.Valuer, time.Time, *time.Time:
+		return nil
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(args[0]))
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		return func(name string) (interface{}, bool) {
+			value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !value.IsValid() {
+				return nil, false
+			}
+			return value.Interface(), true
+		}
+	case reflect.Struct:
+		cols := structColumns(v)
+		return func(name string) (interface{}, bool) {
+			for _, col := range cols {
+				if strings.EqualFold(col.name, name) {
+					return col.value, true
+				}
+			}
+			return nil, false
+		}
+	}
+	return nil
+}
@@ -5,7 +5,10 @@
 package builder
 
 import (
+	sql2 "database/sql"
+	"database/sql/driver"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
 )
@@ -28,210 +31,240 @@ func (condIn condIn) handleBlank(w Writer) error {
 }
 
 func (condIn condIn) WriteTo(w Writer) error {
-	if len(condIn.vals) <= 0 {
-		return condIn.handleBlank(w)
-	}
+	return inWriteTo(w, condIn.col, "IN", condIn.vals, condIn.handleBlank)
+}
 
-	switch condIn.vals[0].(type) {
-	case []int8:
-		vals := condIn.vals[0].([]int8)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
+func (condIn condIn) And(conds ...Cond) Cond {
+	return And(condIn, And(conds...))
+}
+
+func (condIn condIn) Or(conds ...Cond) Cond {
+	return Or(condIn, Or(conds...))
+}
+
+func (condIn condIn) IsValid() bool {
+	return len(condIn.col) > 0 && len(condIn.vals) > 0
+}
+
+// inWriteTo writes an IN (or NOT IN) condition of a sub-query or a list of values
+func inWriteTo(w Writer, col, op string, vals []interface{}, handleBlank func(Writer) error) error {
+	if len(vals) == 1 {
+		var sub interface{ WriteTo(Writer) error }
+		switch t := vals[0].(type) {
+		case expr:
+			sub = t
+		case *Builder:
+			sub = t
+		}
+		if sub != nil {
+			if _, err := fmt.Fprintf(w, "%s %s (", quoteName(w, col), op); err != nil {
+				return err
+			}
+			if err := sub.WriteTo(w); err != nil {
+				return err
+			}
+			_, err := fmt.Fprint(w, ")")
 			return err
 		}
-		for _, val := range vals {
-			w.Append(val)
-		}
-	case []int16:
-		vals := condIn.vals[0].([]int16)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
+	}
+
+	values := inValues(vals)
+	if len(values) == 0 {
+		return handleBlank(w)
+	}
+	if ok, err := limitedInListWriteTo(w, col, op, values); ok || err != nil {
+		return err
+	}
+
+	if err := inListWriteTo(w, col, op, len(values)); err != nil {
+		return err
+	}
+	w.Append(values...)
+	return nil
+}
+
+// inPlaceholders are sliced by the lists within them instead of being repeated for every list
+var inPlaceholders = strings.Repeat(",?", 256)
+
+// inListWriteTo writes an IN (or NOT IN) list with n placeholders
+func inListWriteTo(w Writer, col, op string, n int) error {
+	placeholders := inPlaceholders
+	if n*2 > len(placeholders) {
+		placeholders = strings.Repeat(",?", n)
+	}
+	for _, s := range []string{quoteName(w, col), " ", op, " (", placeholders[1 : n*2], ")"} {
+		if _, err := io.WriteString(w, s); err != nil {
 			return err
 		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+	}
+	return nil
+}
+
+// inValues returns the values of an IN list. A single slice or array is expanded to its items, except
+// arrays implementing driver.Valuer (e.g. uuid.UUID). Slices of common types are expanded without reflection
+func inValues(vals []interface{}) []interface{} {
+	if len(vals) != 1 {
+		return vals
+	}
+
+	switch t := vals[0].(type) {
+	case []interface{}:
+		return t
 	case []int:
-		vals := condIn.vals[0].([]int)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+		return boxValues(t)
+	case []int8:
+		return boxValues(t)
+	case []int16:
+		return boxValues(t)
 	case []int32:
-		vals := condIn.vals[0].([]int32)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+		return boxValues(t)
 	case []int64:
-		vals := condIn.vals[0].([]int64)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+		return boxValues(t)
+	case []uint:
+		return boxValues(t)
 	case []uint8:
-		vals := condIn.vals[0].([]uint8)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+		return boxValues(t)
 	case []uint16:
-		vals := condIn.vals[0].([]uint16)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
-	case []uint:
-		vals := condIn.vals[0].([]uint)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+		return boxValues(t)
 	case []uint32:
-		vals := condIn.vals[0].([]uint32)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+		return boxValues(t)
 	case []uint64:
-		vals := condIn.vals[0].([]uint64)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
+		return boxValues(t)
+	case []float32:
+		return boxValues(t)
+	case []float64:
+		return boxValues(t)
 	case []string:
-		vals := condIn.vals[0].([]string)
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		for _, val := range vals {
-			w.Append(val)
-		}
-	case []interface{}:
-		vals := condIn.vals[0].([]interface{})
-		if len(vals) <= 0 {
-			return condIn.handleBlank(w)
-		}
-		questionMark := strings.Repeat("?,", len(vals))
-		if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-			return err
-		}
-		w.Append(vals...)
-	case expr:
-		val := condIn.vals[0].(expr)
-		if _, err := fmt.Fprintf(w, "%s IN (", condIn.col); err != nil {
-			return err
-		}
-		if err := val.WriteTo(w); err != nil {
-			return err
-		}
-		if _, err := fmt.Fprintf(w, ")"); err != nil {
-			return err
-		}
-	case *Builder:
-		bd := condIn.vals[0].(*Builder)
-		if _, err := fmt.Fprintf(w, "%s IN (", condIn.col); err != nil {
-			return err
+		return boxValues(t)
+	}
+
+	v := reflect.ValueOf(vals[0])
+	switch v.Kind() {
+	case reflect.Array:
+		if _, ok := vals[0].(driver.Valuer); ok {
+			return vals
 		}
-		if err := bd.WriteTo(w); err != nil {
-			return err
+	case reflect.Slice:
+	default:
+		return vals
+	}
+
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
+
+// boxValues converts a slice to the args
+func boxValues[T any](vals []T) []interface{} {
+	values := make([]interface{}, len(vals))
+	for i, val := range vals {
+		values[i] = val
+	}
+	return values
+}
+
+// limitedInListWriteTo writes an IN (or NOT IN) list exceeding the limits of the dialect, it returns
+// false if the list is within them. A list with too many items is split into several lists joined by
+// OR (or AND for NOT IN), and a list which would make the statement bind too many arguments is written
+// as literals.
+func limitedInListWriteTo(w Writer, col, op string, values []interface{}) (bool, error) {
+	dialect := writerSQLDialect(w)
+	limiter, ok := dialect.(InListLimiter)
+	if !ok {
+		return false, nil
+	}
+	maxItems, maxArgs := limiter.InListLimits()
+	n := len(values)
+	// the arguments written before the list count against the limit of the statement
+	var written int
+	if bw, ok := w.(*BytesWriter); ok {
+		written = len(bw.args)
+	}
+	inline := maxArgs > 0 && written+n > maxArgs
+	if (maxItems <= 0 || n <= maxItems) && !inline {
+		return false, nil
+	}
+
+	var literal func(interface{}) (string, error)
+	if inline {
+		literal = dialect.FormatLiteral
+	}
+	size := n
+	if maxItems > 0 && n > maxItems {
+		size = maxItems
+	}
+	join := " OR "
+	if op == "NOT IN" {
+		join = " AND "
+	}
+
+	if size < n {
+		if _, err := fmt.Fprint(w, "("); err != nil {
+			return true, err
 		}
-		if _, err := fmt.Fprintf(w, ")"); err != nil {
-			return err
+	}
+	for start := 0; start < n; start += size {
+		if start > 0 {
+			if _, err := fmt.Fprint(w, join); err != nil {
+				return true, err
+			}
 		}
-	default:
-		v := reflect.ValueOf(condIn.vals[0])
-		if v.Kind() == reflect.Slice {
-			l := v.Len()
-			if l == 0 {
-				return condIn.handleBlank(w)
+		chunk := values[start:min(start+size, n)]
+		if literal == nil {
+			if err := inListWriteTo(w, col, op, len(chunk)); err != nil {
+				return true, err
 			}
+			w.Append(chunk...)
+			continue
+		}
 
-			questionMark := strings.Repeat("?,", l)
-			if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-				return err
+		if _, err := fmt.Fprintf(w, "%s %s (", quoteName(w, col), op); err != nil {
+			return true, err
+		}
+		for i, val := range chunk {
+			if i > 0 {
+				if _, err := fmt.Fprint(w, ","); err != nil {
+					return true, err
+				}
 			}
-
-			for i := 0; i < l; i++ {
-				w.Append(v.Index(i).Interface())
+			if bindOnly(val) {
+				if _, err := fmt.Fprint(w, "?"); err != nil {
+					return true, err
+				}
+				w.Append(val)
+				continue
 			}
-		} else {
-			questionMark := strings.Repeat("?,", len(condIn.vals))
-			if _, err := fmt.Fprintf(w, "%s IN (%s)", condIn.col, questionMark[:len(questionMark)-1]); err != nil {
-				return err
+			s, err := literal(val)
+			if err != nil {
+				return true, err
 			}
-			w.Append(condIn.vals...)
+			if _, err := fmt.Fprint(w, s); err != nil {
+				return true, err
+			}
+		}
+		if _, err := fmt.Fprint(w, ")"); err != nil {
+			return true, err
 		}
 	}
-	return nil
-}
-
-func (condIn condIn) And(conds ...Cond) Cond {
-	return And(condIn, And(conds...))
-}
-
-func (condIn condIn) Or(conds ...Cond) Cond {
-	return Or(condIn, Or(conds...))
+	if size < n {
+		if _, err := fmt.Fprint(w, ")"); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
 }
 
-func (condIn condIn) IsValid() bool {
-	return len(condIn.col) > 0 && len(condIn.vals) > 0
+// bindOnly tests if a value of an inlined IN list is still bound as an argument, Params are bound
+// later by Template.Bind and can't be written as literals
+func bindOnly(val interface{}) bool {
+	if namedArg, ok := val.(sql2.NamedArg); ok {
+		val = namedArg.Value
+	}
+	switch val.(type) {
+	case Param, expr:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,123 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	sql2 "database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type uuid [2]byte
+
+func (u uuid) Value() (driver.Value, error) {
+	return fmt.Sprintf("%x", u[:]), nil
+}
+
+func TestCond_In(t *testing.T) {
+	type score float64
+
+	cases := []struct {
+		cond Cond
+		sql  string
+		args []interface{}
+	}{
+		{In("a", []float64{1.5, 2}), "a IN (?,?)", []interface{}{1.5, float64(2)}},
+		{In("a", []score{1.5}), "a IN (?)", []interface{}{score(1.5)}},
+		{In("a", [2]int{1, 2}), "a IN (?,?)", []interface{}{1, 2}},
+		{In("a", []uuid{{1, 2}, {3, 4}}), "a IN (?,?)", []interface{}{uuid{1, 2}, uuid{3, 4}}},
+		// a driver.Valuer array is a single value
+		{In("a", uuid{1, 2}), "a IN (?)", []interface{}{uuid{1, 2}}},
+		{NotIn("a", []score{}), "0=0", nil},
+		{NotIn("a", Select("id").From("t").Where(Eq{"b": 1})), "a NOT IN (SELECT id FROM t WHERE b=?)", []interface{}{1}},
+	}
+
+	for _, c := range cases {
+		sql, args, err := ToSQL(c.cond)
+		assert.NoError(t, err)
+		assert.EqualValues(t, c.sql, sql)
+		assert.EqualValues(t, c.args, args)
+	}
+}
+
+func TestCond_InListLimits(t *testing.T) {
+	ids := make([]int, 2500)
+	for i := range ids {
+		ids[i] = i
+	}
+
+	// Oracle splits the list into lists of 1000 items
+	sql, args, err := Oracle().Select("a").From("t").Where(In("id", ids).And(Eq{"b": 1})).ToSQL()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sql, "SELECT a FROM t WHERE (id IN (:p1,"))
+	assert.True(t, strings.HasSuffix(sql, ",:p2500)) AND b=:p2501"))
+	assert.EqualValues(t, 3, strings.Count(sql, "id IN ("))
+	assert.EqualValues(t, 2, strings.Count(sql, ") OR id IN ("))
+	assert.EqualValues(t, 2501, len(args))
+
+	sql, _, err = Oracle().Select("a").From("t").Where(NotIn("id", ids[:1001])).ToSQL()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(sql, ",:p1000) AND id NOT IN (:p1001))"))
+
+	// MSSQL writes the values as literals instead of exceeding the limit of parameters
+	sql, args, err = MsSQL().Select("a").From("t").Where(In("id", ids).And(Eq{"b": "x"})).ToSQL()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sql, "SELECT a FROM t WHERE id IN (0,1,2,"))
+	assert.True(t, strings.HasSuffix(sql, ",2498,2499) AND b=@p1"))
+	assert.EqualValues(t, 1, len(args))
+
+	// the arguments of the whole statement are counted
+	sql, args, err = MsSQL().Select("a").From("t").Where(In("id", ids[:1500]).And(In("b", ids[:1500]))).ToSQL()
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(sql, ",@p1500) AND b IN (0,1,2,"))
+	assert.True(t, strings.HasSuffix(sql, ",1498,1499)"))
+	assert.EqualValues(t, 1500, len(args))
+
+	names := make([]string, 2001)
+	for i := range names {
+		names[i] = "é"
+	}
+	sql, args, err = MsSQL().Select("a").From("t").Where(In("name", names)).ToSQL()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sql, "SELECT a FROM t WHERE name IN (N'é',N'é',"))
+	assert.EqualValues(t, 0, len(args))
+
+	// Params of an inlined list are still bound by the template
+	values := make([]interface{}, 2101)
+	for i := range values {
+		values[i] = i
+	}
+	values[0] = Param("p")
+	tmpl, err := MsSQL().Select("a").From("t").Where(In("id", values...)).Compile()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(tmpl.SQL(), "SELECT a FROM t WHERE id IN (@p1,1,2,"))
+	args, err = tmpl.Bind(map[string]interface{}{"p": 7})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{sql2.Named("p1", 7)}, args)
+
+	_, _, err = MsSQL().Select("a").From("t").Where(In("id", values...)).ToSQL()
+	assert.EqualError(t, err, ErrUnboundParam.Error())
+
+	sql, args, err = MsSQL().Select("a").From("t").Where(NotIn("name", []string{"it's"})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE name NOT IN (@p1)", sql)
+	assert.EqualValues(t, 1, len(args))
+
+	// the limit of parameters is the one splitting batch inserts
+	sql, args, err = SQLite().Select("a").From("t").Where(In("id", ids)).ToSQL()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sql, "SELECT a FROM t WHERE id IN (0,1,2,"))
+	assert.EqualValues(t, 0, len(args))
+
+	// other databases are far from their limits
+	sql, args, err = Postgres().Select("a").From("t").Where(In("id", ids)).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, strings.Count(sql, "IN"))
+	assert.EqualValues(t, 2500, len(args))
+}
@@ -26,7 +26,7 @@ func (neq Neq) WriteTo(w Writer) error {
 				return err
 			}
 		case expr:
-			if _, err := fmt.Fprintf(w, "%s<>(", k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s<>(", quoteName(w, k)); err != nil {
 				return err
 			}
 
@@ -38,7 +38,7 @@ func (neq Neq) WriteTo(w Writer) error {
 				return err
 			}
 		case *Builder:
-			if _, err := fmt.Fprintf(w, "%s<>(", k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s<>(", quoteName(w, k)); err != nil {
 				return err
 			}
 
@@ -50,7 +50,7 @@ func (neq Neq) WriteTo(w Writer) error {
 				return err
 			}
 		default:
-			if _, err := fmt.Fprintf(w, "%s<>?", k); err != nil {
+			if _, err := fmt.Fprintf(w, "%s<>?", quoteName(w, k)); err != nil {
 				return err
 			}
 			args = append(args, v)
@@ -0,0 +1,198 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is an optional feature of SQL which is not supported by every database
+type Feature int
+
+const (
+	FeatureReturning     Feature = iota // RETURNING (or OUTPUT) clause of INSERT, UPDATE and DELETE
+	FeatureNullsOrdering                // NULLS FIRST and NULLS LAST in ORDER BY
+	FeatureRowLocking                   // FOR UPDATE and FOR SHARE
+	FeatureLateralJoin                  // LATERAL joins
+	FeatureRowValues                    // comparison of row values, e.g. (a,b)>(?,?)
+	FeatureRollup                       // ROLLUP in GROUP BY, or the WITH ROLLUP modifier of MySQL
+	FeatureGroupingSets                 // ROLLUP, CUBE and GROUPING SETS items mixed in GROUP BY
+)
+
+// SQLDialect describes how SQL is written for a database. Statements which differ in syntax
+// between databases are written in the way of the built-in dialect returned by Family, so a
+// compatible database (e.g. CockroachDB to Postgres or TiDB to MySQL) only needs to be
+// registered with the built-in one, while others follow the standard syntax.
+type SQLDialect interface {
+	// Family returns the built-in dialect whose syntax is followed, or empty
+	Family() string
+	// Placeholder returns the placeholder of the n-th argument (starting from 1), and its name
+	// if the argument should be passed as a sql.NamedArg
+	Placeholder(n int) (placeholder string, name string)
+	// Quote quotes an identifier
+	Quote(name string) string
+	// LimitClause returns the clause written after ORDER BY to paginate, including the leading
+	// space. Empty means limit is emulated by sub-queries, which only works for MSSQL and Oracle
+	LimitClause(limitN, offset int, ordered bool) string
+	// FormatLiteral formats an argument as a literal for ToBoundSQL
+	FormatLiteral(arg interface{}) (string, error)
+	// Supports tests if an optional feature is supported
+	Supports(feature Feature) bool
+}
+
+// InListLimiter could be implemented by a SQLDialect whose database limits the size of IN lists
+type InListLimiter interface {
+	// InListLimits returns the max number of items in an IN list, a longer list is split into
+	// several ones, and the max number of arguments bound by a statement, a list which would
+	// exceed it with the arguments before is written as literals instead and batch inserts are
+	// split by it. Zero means no limit
+	InListLimits() (maxItems, maxArgs int)
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]SQLDialect{
+		POSTGRES: &builtinDialect{family: POSTGRES, bindPrefix: "$", quotes: `""`, maxArgs: 65535},
+		SQLITE: &builtinDialect{family: SQLITE, quotes: `""`, maxArgs: 999,
+			unsupported: []Feature{FeatureRowLocking, FeatureLateralJoin, FeatureRollup, FeatureGroupingSets}},
+		MYSQL: &builtinDialect{family: MYSQL, quotes: "``", maxArgs: 65535,
+			unsupported: []Feature{FeatureReturning, FeatureNullsOrdering, FeatureGroupingSets}},
+		MSSQL: &builtinDialect{family: MSSQL, bindPrefix: "@p", named: true, quotes: "[]", maxArgs: 2000,
+			unsupported: []Feature{FeatureNullsOrdering, FeatureRowValues}},
+		MSSQL2012: &builtinDialect{family: MSSQL, bindPrefix: "@p", named: true, quotes: "[]", offsetFetch: true, maxArgs: 2000,
+			unsupported: []Feature{FeatureNullsOrdering, FeatureRowValues}},
+		ORACLE: &builtinDialect{family: ORACLE, bindPrefix: ":p", named: true, quotes: `""`, maxInItems: 1000, maxArgs: 65535,
+			unsupported: []Feature{FeatureRowValues}},
+		ORACLE12C: &builtinDialect{family: ORACLE, bindPrefix: ":p", named: true, quotes: `""`, offsetFetch: true, maxInItems: 1000, maxArgs: 65535,
+			unsupported: []Feature{FeatureRowValues}},
+	}
+)
+
+// RegisterDialect makes a dialect available by the name, which could be used by Dialect(name).
+// A dialect registered with an existing name replaces the former one.
+func RegisterDialect(name string, dialect SQLDialect) {
+	if dialect == nil {
+		panic("builder: RegisterDialect dialect is nil")
+	}
+
+	dialectsMu.Lock()
+	dialects[strings.ToLower(strings.TrimSpace(name))] = dialect
+	dialectsMu.Unlock()
+}
+
+// unregisterDialect removes the dialect registered with the name
+func unregisterDialect(name string) {
+	dialectsMu.Lock()
+	delete(dialects, strings.ToLower(strings.TrimSpace(name)))
+	dialectsMu.Unlock()
+}
+
+// LookupDialect returns the dialect registered with the name, or nil. Names are case insensitive
+func LookupDialect(name string) SQLDialect {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	return dialects[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// dialectFamily returns the built-in dialect whose syntax is followed by the dialect
+func dialectFamily(dialect string) string {
+	d := LookupDialect(dialect)
+	if d == nil || d.Family() == "" {
+		return dialect
+	}
+	return d.Family()
+}
+
+// family returns the built-in dialect whose syntax is followed by the builder
+func (b *Builder) family() string {
+	return dialectFamily(b.dialect)
+}
+
+// lacks tests if a registered dialect doesn't support the feature, the others are
+// supposed to follow the standard
+func lacks(dialect string, feature Feature) bool {
+	d := LookupDialect(dialect)
+	return d != nil && !d.Supports(feature)
+}
+
+type builtinDialect struct {
+	family      string
+	bindPrefix  string // prefix of numbered placeholders, ? is used if it's empty
+	named       bool   // arguments are named as the placeholders without the first character
+	quotes      string // opening and closing quote characters
+	offsetFetch bool   // OFFSET ... FETCH pagination of MSSQL and Oracle
+	maxInItems  int    // Oracle rejects more than 1000 items in an IN list
+	maxArgs     int    // bind parameters of a statement, MSSQL rejects more than 2100 so some are left to the driver
+	unsupported []Feature
+}
+
+var (
+	_ SQLDialect    = &builtinDialect{}
+	_ InListLimiter = &builtinDialect{}
+)
+
+func (d *builtinDialect) Family() string {
+	return d.family
+}
+
+func (d *builtinDialect) Placeholder(n int) (string, string) {
+	if d.bindPrefix == "" {
+		return "?", ""
+	}
+
+	placeholder := d.bindPrefix + strconv.Itoa(n)
+	if d.named {
+		return placeholder, placeholder[1:]
+	}
+	return placeholder, ""
+}
+
+func (d *builtinDialect) Quote(name string) string {
+	return d.quotes[:1] + name + d.quotes[1:]
+}
+
+func (d *builtinDialect) LimitClause(limitN, offset int, ordered bool) string {
+	switch d.family {
+	case MSSQL, ORACLE:
+		if !d.offsetFetch {
+			return ""
+		}
+
+		var clause string
+		// MSSQL requires ORDER BY and OFFSET before FETCH
+		if d.family == MSSQL && !ordered {
+			clause = " ORDER BY (SELECT NULL)"
+		}
+		if d.family == MSSQL || offset > 0 {
+			clause += fmt.Sprintf(" OFFSET %d ROWS", offset)
+		}
+		return clause + fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", limitN)
+	}
+
+	if offset == 0 {
+		return fmt.Sprintf(" LIMIT %d", limitN)
+	}
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", limitN, offset)
+}
+
+func (d *builtinDialect) FormatLiteral(arg interface{}) (string, error) {
+	return dialectLiteral(d.family, arg)
+}
+
+func (d *builtinDialect) InListLimits() (int, int) {
+	return d.maxInItems, d.maxArgs
+}
+
+func (d *builtinDialect) Supports(feature Feature) bool {
+	for _, f := range d.unsupported {
+		if f == feature {
+			return false
+		}
+	}
+	return true
+}
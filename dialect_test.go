@@ -0,0 +1,105 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clickHouse struct {
+	SQLDialect
+}
+
+func (clickHouse) Family() string {
+	return ""
+}
+
+func (clickHouse) LimitClause(limitN, offset int, ordered bool) string {
+	return fmt.Sprintf(" LIMIT %d, %d", offset, limitN)
+}
+
+func (clickHouse) FormatLiteral(arg interface{}) (string, error) {
+	if s, ok := arg.(string); ok {
+		return fmt.Sprintf("'%s'", s), nil
+	}
+	return formatLiteral(arg)
+}
+
+func (clickHouse) Supports(feature Feature) bool {
+	return feature == FeatureNullsOrdering
+}
+
+func TestRegisterDialect(t *testing.T) {
+	t.Cleanup(func() {
+		unregisterDialect("cockroachdb")
+		unregisterDialect("clickhouse")
+	})
+
+	// a compatible database is registered with the built-in dialect
+	RegisterDialect("cockroachdb", LookupDialect(POSTGRES))
+	sql, args, err := Dialect("cockroachdb").Select("a").From("t").Where(Eq{"b": 1}).Limit(10, 5).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE b=$1 LIMIT 10 OFFSET 5", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, _, err = Dialect("CockroachDB").Insert(Eq{"a": 1}).Into("t").Upsert([]string{"a"}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INSERT INTO t (a) Values ($1) ON CONFLICT (a) DO NOTHING", sql)
+
+	RegisterDialect("clickhouse", clickHouse{LookupDialect(MYSQL)})
+	sql, args, err = Dialect("clickhouse").Select("a").From("t").Where(Eq{"b": "x"}).
+		OrderBy(Asc("a").NullsFirst()).Limit(10, 5).QuoteIdentifiers().ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT `a` FROM `t` WHERE `b`=? ORDER BY `a` ASC NULLS FIRST LIMIT 5, 10", sql)
+	assert.EqualValues(t, []interface{}{"x"}, args)
+
+	sql, err = Dialect("clickhouse").Select("a").From("t").Where(Eq{"b": "x"}).ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE b='x'", sql)
+
+	// statements of built-in dialects are not supported
+	_, _, err = Dialect("clickhouse").Insert(Eq{"a": 1}).Into("t").Upsert([]string{"a"}).ToSQL()
+	assert.EqualError(t, err, ErrNotSupportDialectType.Error())
+
+	_, _, err = Dialect("clickhouse").Delete(Eq{"a": 1}).From("t").Returning("a").ToSQL()
+	assert.EqualError(t, err, ErrNotSupportDialectType.Error())
+
+	_, _, err = Dialect("clickhouse").Select("a").From("t").ForUpdate().ToSQL()
+	assert.EqualError(t, err, ErrNotSupportDialectType.Error())
+
+	_, _, err = Dialect("clickhouse").Select("a", "count(*)").From("t").GroupBy(Rollup("a")).ToSQL()
+	assert.EqualError(t, err, ErrNotSupportDialectType.Error())
+
+	unregisterDialect("CockroachDB")
+	assert.Nil(t, LookupDialect("cockroachdb"))
+}
+
+func TestBuiltinDialects(t *testing.T) {
+	for _, dialect := range []string{POSTGRES, SQLITE, MYSQL, MSSQL, MSSQL2012, ORACLE, ORACLE12C} {
+		assert.NotNil(t, LookupDialect(dialect), dialect)
+	}
+	assert.Nil(t, LookupDialect("unknown"))
+
+	placeholder, name := LookupDialect(MSSQL).Placeholder(2)
+	assert.EqualValues(t, "@p2", placeholder)
+	assert.EqualValues(t, "p2", name)
+
+	placeholder, name = LookupDialect(POSTGRES).Placeholder(2)
+	assert.EqualValues(t, "$2", placeholder)
+	assert.EqualValues(t, "", name)
+
+	assert.EqualValues(t, "[a]", LookupDialect(MSSQL2012).Quote("a"))
+	assert.EqualValues(t, "", LookupDialect(ORACLE).LimitClause(10, 0, false))
+	assert.EqualValues(t, " FETCH NEXT 10 ROWS ONLY", LookupDialect(ORACLE12C).LimitClause(10, 0, false))
+	assert.False(t, LookupDialect(SQLITE).Supports(FeatureRowLocking))
+	assert.True(t, LookupDialect(POSTGRES).Supports(FeatureLateralJoin))
+	assert.False(t, LookupDialect(ORACLE12C).Supports(FeatureRowValues))
+	assert.True(t, LookupDialect(MYSQL).Supports(FeatureRollup))
+	assert.False(t, LookupDialect(MYSQL).Supports(FeatureGroupingSets))
+	assert.False(t, LookupDialect(SQLITE).Supports(FeatureRollup))
+}
@@ -21,6 +21,16 @@ var (
 	ErrNoColumnToUpdate = errors.New("No column(s) to update")
 	// ErrNoColumnToInsert no column to update
 	ErrNoColumnToInsert = errors.New("No column(s) to insert")
+	// ErrInconsistentInsertRows rows in batch insert have different columns
+	ErrInconsistentInsertRows = errors.New("Inconsistent columns in rows to insert")
+	// ErrNoWhereCondition no condition to update or delete
+	ErrNoWhereCondition = errors.New("No where condition to update or delete, try to use `All()` to modify all rows")
+	// ErrNoPrimaryKey no pk field in the struct to update
+	ErrNoPrimaryKey = errors.New("No pk field in the struct to update")
+	// ErrNoColumnToReturn no column to return
+	ErrNoColumnToReturn = errors.New("No column(s) to return")
+	// ErrNoConflictTarget no conflict target columns for upsert
+	ErrNoConflictTarget = errors.New("No conflict target column(s) to upsert")
 	// ErrNotSupportDialectType not supported dialect type error
 	ErrNotSupportDialectType = errors.New("Not supported dialect type")
 	// ErrNotUnexpectedUnionConditions using union in a wrong way
@@ -31,10 +41,24 @@ var (
 	ErrUnexpectedSubQuery = errors.New("Unexpected sub-query in SELECT query")
 	// ErrDialectNotSetUp dialect is not setup yet
 	ErrDialectNotSetUp = errors.New("Dialect is not setup yet, try to use `Dialect(dbType)` at first")
+	// ErrInvalidOrderColumn Asc or Desc is given something other than a column name or an Expr
+	ErrInvalidOrderColumn = errors.New("Invalid column to order by, try to use `Expr` for an expression")
 	// ErrInvalidLimitation offset or limit is not correct
 	ErrInvalidLimitation = errors.New("Offset or limit is not correct")
 	// ErrUnnamedDerivedTable Every derived table must have its own alias
 	ErrUnnamedDerivedTable = errors.New("Every derived table must have its own alias")
 	// ErrInconsistentDialect Inconsistent dialect in same builder
 	ErrInconsistentDialect = errors.New("Inconsistent dialect in same builder")
+	// ErrNoNamedArgument no argument for a named placeholder
+	ErrNoNamedArgument = errors.New("No argument for the named placeholder")
+	// ErrInconsistentNamedArgument different values with the same name
+	ErrInconsistentNamedArgument = errors.New("Inconsistent values of the named argument")
+	// ErrUnboundParam no value bound to a param of template
+	ErrUnboundParam = errors.New("No value bound to the param")
+	// ErrNoKeyset no key columns to seek
+	ErrNoKeyset = errors.New("No key column(s) to seek")
+	// ErrInconsistentKeyset the number of values to seek is different from the keys
+	ErrInconsistentKeyset = errors.New("Inconsistent values of the keyset")
+	// ErrInvalidCursor the cursor can't be decoded
+	ErrInvalidCursor = errors.New("Invalid cursor")
 )
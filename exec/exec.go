@@ -0,0 +1,84 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package exec runs builders against database/sql. The statements are written by
+// the dialects of the builders, so placeholders match the drivers of them.
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-xorm/builder"
+)
+
+// ErrInvalidDest dest is not a pointer to struct, map, slice or scannable value
+var ErrInvalidDest = errors.New("Destination should be a pointer to struct, map, slice or scannable value")
+
+// Execer executes statements, it's implemented by *sql.DB, *sql.Tx and *sql.Conn
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Queryer runs queries, it's implemented by *sql.DB, *sql.Tx and *sql.Conn
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Exec executes the statement of a builder
+func Exec(ctx context.Context, db Execer, b *builder.Builder) (sql.Result, error) {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return db.ExecContext(ctx, query, args...)
+}
+
+// Query runs the query of a builder
+func Query(ctx context.Context, db Queryer, b *builder.Builder) (*sql.Rows, error) {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryContext(ctx, query, args...)
+}
+
+// Row is the result of QueryRow
+type Row struct {
+	rows *sql.Rows
+	err  error
+}
+
+// QueryRow runs the query of a builder which returns at most one row, errors are
+// deferred until Row's Scan is called
+func QueryRow(ctx context.Context, db Queryer, b *builder.Builder) *Row {
+	rows, err := Query(ctx, db, b)
+	return &Row{rows: rows, err: err}
+}
+
+// Scan copies the columns of the first row into dest like sql.Row, sql.ErrNoRows
+// is returned if there is no row
+func (r *Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+	return r.rows.Close()
+}
+
+// Err returns the error of running the query if there is one
+func (r *Row) Err() error {
+	return r.err
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/go-xorm/builder"
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+)
+
+type model struct {
+	ID int64 `db:"id,pk,readonly"`
+}
+
+type user struct {
+	model
+	Name     string         `db:"name"`
+	Nickname sql.NullString `db:"nickname,omitempty"`
+	Age      int
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	assert.NoError(t, err)
+	// every connection has its own memory database
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE user (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, nickname TEXT, age INTEGER NOT NULL)")
+	assert.NoError(t, err)
+	return db
+}
+
+func TestExec(t *testing.T) {
+	db := openDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	res, err := Exec(ctx, db, builder.SQLite().Insert([]user{{Name: "a", Age: 18}, {Name: "b", Age: 20}}).Into("user"))
+	assert.NoError(t, err)
+	affected, err := res.RowsAffected()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, affected)
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	_, err = Exec(ctx, tx, builder.SQLite().UpdateStruct(&user{model{2}, "b", sql.NullString{String: "bb", Valid: true}, 21}).From("user"))
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	var name string
+	var age int
+	err = QueryRow(ctx, db, builder.SQLite().Select("name", "age").From("user").Where(builder.Eq{"id": 2})).Scan(&name, &age)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "b", name)
+	assert.EqualValues(t, 21, age)
+
+	err = QueryRow(ctx, db, builder.SQLite().Select("name").From("user").Where(builder.Eq{"id": 3})).Scan(&name)
+	assert.EqualValues(t, sql.ErrNoRows, err)
+
+	err = QueryRow(ctx, db, builder.SQLite().Select("name")).Scan(&name)
+	assert.EqualError(t, err, builder.ErrNoTableName.Error())
+
+	rows, err := Query(ctx, db, builder.SQLite().Select("id").From("user").OrderBy("id"))
+	assert.NoError(t, err)
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		assert.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	assert.NoError(t, rows.Close())
+	assert.EqualValues(t, []int64{1, 2}, ids)
+}
+
+func TestGetAndFind(t *testing.T) {
+	db := openDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	_, err := Exec(ctx, db, builder.SQLite().Insert([]builder.Eq{
+		{"name": "a", "age": 18, "nickname": nil},
+		{"name": "b", "age": 20, "nickname": "bb"},
+	}).Into("user"))
+	assert.NoError(t, err)
+
+	var u user
+	err = Get(ctx, db, builder.SQLite().Select("*").From("user").Where(builder.Eq{"name": "b"}), &u)
+	assert.NoError(t, err)
+	assert.EqualValues(t, user{model{2}, "b", sql.NullString{String: "bb", Valid: true}, 20}, u)
+
+	var count int
+	err = Get(ctx, db, builder.SQLite().Select("count(*)").From("user"), &count)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	var m map[string]interface{}
+	err = Get(ctx, db, builder.SQLite().Select("id", "name").From("user").Where(builder.Eq{"id": 1}), &m)
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"id": int64(1), "name": "a"}, m)
+
+	err = Get(ctx, db, builder.SQLite().Select("*").From("user").Where(builder.Eq{"id": 3}), &u)
+	assert.EqualValues(t, sql.ErrNoRows, err)
+
+	var users []*user
+	err = Find(ctx, db, builder.SQLite().Select("id", "name", "age", "1 AS unknown").From("user").OrderBy("id"), &users)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*user{{model{1}, "a", sql.NullString{}, 18}, {model{2}, "b", sql.NullString{}, 20}}, users)
+
+	var names []string
+	err = Find(ctx, db, builder.SQLite().Select("name").From("user").OrderBy("id"), &names)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"a", "b"}, names)
+
+	var maps []map[string]interface{}
+	err = Find(ctx, db, builder.SQLite().Select("name").From("user").Where(builder.Gt{"age": 18}), &maps)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []map[string]interface{}{{"name": "b"}}, maps)
+
+	err = Find(ctx, db, builder.SQLite().Select("name", "age").From("user"), &names)
+	assert.EqualError(t, err, ErrInvalidDest.Error())
+
+	err = Find(ctx, db, builder.SQLite().Select("name").From("user"), names)
+	assert.EqualError(t, err, ErrInvalidDest.Error())
+}
@@ -0,0 +1,225 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-xorm/builder"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+	mapType     = reflect.TypeOf(map[string]interface{}{})
+)
+
+// Get runs the query of a builder and scans the first row into dest, which could be a pointer
+// to struct, map[string]interface{} or a scannable value. sql.ErrNoRows is returned if there is no row
+func Get(ctx context.Context, db Queryer, b *builder.Builder, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrInvalidDest
+	}
+
+	rows, err := Query(ctx, db, b)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+
+	s, err := newScanner(rows, v.Elem().Type())
+	if err != nil {
+		return err
+	}
+	if err := s.scan(rows, v.Elem()); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// Find runs the query of a builder and appends all the rows to dest, which should be a pointer
+// to a slice of structs, pointers to struct, map[string]interface{} or scannable values
+func Find(ctx context.Context, db Queryer, b *builder.Builder, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return ErrInvalidDest
+	}
+	slice := v.Elem()
+
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	rows, err := Query(ctx, db, b)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	s, err := newScanner(rows, elemType)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		elem := reflect.New(elemType)
+		if err := s.scan(rows, elem.Elem()); err != nil {
+			return err
+		}
+		if isPtr {
+			slice = reflect.Append(slice, elem)
+		} else {
+			slice = reflect.Append(slice, elem.Elem())
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	v.Elem().Set(slice)
+	return rows.Close()
+}
+
+// scanner scans rows into values of a type
+type scanner struct {
+	cols []string
+	// index paths of the struct fields of columns, nil for the columns without fields
+	fields [][]int
+	kind   reflect.Kind
+}
+
+func newScanner(rows *sql.Rows, t reflect.Type) (*scanner, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &scanner{cols: cols, kind: t.Kind()}
+	switch {
+	case scannable(t):
+		if len(cols) != 1 {
+			return nil, ErrInvalidDest
+		}
+		s.kind = reflect.Invalid
+	case t.Kind() == reflect.Struct:
+		var fields = make(map[string][]int)
+		structFields(t, nil, fields)
+
+		s.fields = make([][]int, len(cols))
+		for i, col := range cols {
+			s.fields[i] = fields[strings.ToLower(col)]
+		}
+	case t == mapType:
+	default:
+		return nil, ErrInvalidDest
+	}
+	return s, nil
+}
+
+// scan scans the current row into v, which should be addressable
+func (s *scanner) scan(rows *sql.Rows, v reflect.Value) error {
+	switch s.kind {
+	case reflect.Struct:
+		var dest = make([]interface{}, len(s.cols))
+		for i, path := range s.fields {
+			dest[i] = new(interface{})
+			if path != nil {
+				if field, ok := fieldByIndex(v, path); ok {
+					dest[i] = field.Addr().Interface()
+				}
+			}
+		}
+		return rows.Scan(dest...)
+	case reflect.Map:
+		var values = make([]interface{}, len(s.cols))
+		var dest = make([]interface{}, len(s.cols))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(mapType))
+		}
+		for i, col := range s.cols {
+			v.SetMapIndex(reflect.ValueOf(col), reflect.ValueOf(&values[i]).Elem())
+		}
+		return nil
+	}
+	return rows.Scan(v.Addr().Interface())
+}
+
+// scannable tests if values of the type are scanned as a whole
+func scannable(t reflect.Type) bool {
+	if reflect.PtrTo(t).Implements(scannerType) || t == timeType {
+		return true
+	}
+	return t.Kind() != reflect.Struct && t.Kind() != reflect.Map
+}
+
+// structFields collects the index paths of the fields by their lower case column names, which
+// are parsed from the db tags as builder's Insert does
+func structFields(t reflect.Type, index []int, fields map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := builder.ParseFieldTag(field)
+		if tag.Ignored {
+			continue
+		}
+
+		path := append(append([]int{}, index...), i)
+		if tag.Inline {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !scannable(ft) {
+				structFields(ft, path, fields)
+				continue
+			}
+		}
+
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+		if _, ok := fields[strings.ToLower(tag.Name)]; !ok {
+			fields[strings.ToLower(tag.Name)] = path
+		}
+	}
+}
+
+// fieldByIndex returns the nested field, nil pointers of embedded structs are allocated.
+// ok is false if the field can't be set, e.g. it's in a nil pointer of unexported struct
+func fieldByIndex(v reflect.Value, path []int) (reflect.Value, bool) {
+	for i, idx := range path {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return v, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	return v, v.CanSet()
+}
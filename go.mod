@@ -1,6 +1,13 @@
 module github.com/go-xorm/builder
 
+go 1.21
+
 require (
 	github.com/go-xorm/sqlfiddle v0.0.0-20180821085327-62ce714f951a
 	github.com/stretchr/testify v1.3.0
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,147 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatLiteral formats an argument as a literal of standard SQL
+func formatLiteral(arg interface{}) (string, error) {
+	return dialectLiteral("", arg)
+}
+
+// dialectLiteral formats an argument as a literal of the built-in dialect family, values of
+// driver.Valuer are formatted by the values they return
+func dialectLiteral(family string, arg interface{}) (string, error) {
+	if valuer, ok := arg.(driver.Valuer); ok {
+		if v := reflect.ValueOf(arg); v.Kind() == reflect.Ptr && v.IsNil() {
+			return "NULL", nil
+		}
+
+		value, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		if _, ok := value.(driver.Valuer); ok {
+			return "", ErrNotSupportType
+		}
+		return dialectLiteral(family, value)
+	}
+
+	switch t := arg.(type) {
+	case nil:
+		return "NULL", nil
+	case time.Time:
+		return timeLiteral(family, t), nil
+	}
+
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "NULL", nil
+		}
+		return dialectLiteral(family, v.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		return boolLiteral(family, v.Bool()), nil
+	case reflect.String:
+		return stringLiteral(family, v.String()), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return "NULL", nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return bytesLiteral(family, v.Bytes()), nil
+		}
+		return arrayLiteral(family, v)
+	case reflect.Array:
+		return arrayLiteral(family, v)
+	}
+
+	return stringLiteral(family, fmt.Sprint(arg)), nil
+}
+
+// arrayLiteral writes a slice or an array as ARRAY[...] of Postgres, other dialects have no
+// array literals
+func arrayLiteral(family string, v reflect.Value) (string, error) {
+	if family != POSTGRES {
+		return "", ErrNotSupportType
+	}
+
+	items := make([]string, v.Len())
+	for i := range items {
+		item, err := dialectLiteral(family, v.Index(i).Interface())
+		if err != nil {
+			return "", err
+		}
+		items[i] = item
+	}
+	return "ARRAY[" + strings.Join(items, ",") + "]", nil
+}
+
+// stringLiteral quotes a string, single quotes are doubled and backslashes are escaped
+// in MySQL as well. MSSQL strings are written as N'...' to keep non-ASCII characters
+func stringLiteral(family, s string) string {
+	switch family {
+	case MYSQL:
+		s = strings.Replace(s, `\`, `\\`, -1)
+	case MSSQL:
+		return "N'" + strings.Replace(s, "'", "''", -1) + "'"
+	}
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// boolLiteral writes booleans as 1 and 0 in the dialects without boolean literals
+func boolLiteral(family string, b bool) string {
+	switch family {
+	case MSSQL, ORACLE, SQLITE:
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	return strconv.FormatBool(b)
+}
+
+func bytesLiteral(family string, bs []byte) string {
+	h := hex.EncodeToString(bs)
+	switch family {
+	case POSTGRES:
+		return `'\x` + h + "'"
+	case MSSQL:
+		return "0x" + h
+	case ORACLE:
+		return "HEXTORAW('" + h + "')"
+	}
+	return "X'" + h + "'"
+}
+
+// timeLiteral writes a time in ISO format which is accepted by the dialect. The time zone is
+// kept in Postgres, the others are written in the local time of t
+func timeLiteral(family string, t time.Time) string {
+	switch family {
+	case POSTGRES:
+		return "'" + t.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+	case MSSQL:
+		// the format with T is not affected by the language settings
+		return "'" + t.Format("2006-01-02T15:04:05.999") + "'"
+	case ORACLE:
+		return "TO_TIMESTAMP('" + t.Format("2006-01-02 15:04:05.000000000") + "', 'YYYY-MM-DD HH24:MI:SS.FF9')"
+	}
+	return "'" + t.Format("2006-01-02 15:04:05.999999") + "'"
+}
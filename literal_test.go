@@ -0,0 +1,57 @@
+// Copyright 2019 The Xorm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package builder
+
+import (
+	sql2 "database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLiteral(t *testing.T) {
+	var nilString *string
+	s := "a"
+	tm := time.Date(2019, 3, 4, 5, 6, 7, 123456000, time.FixedZone("", 8*3600))
+
+	newSQL, err := ConvertToBoundSQL("?,?,?,?,?,?,?,?,?,?,?", []interface{}{
+		"it's", nil, nilString, &s, []byte("ab"), tm, false, time.Second, 1.5,
+		sql2.NullString{String: "x", Valid: true}, sql2.NullInt64{},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, `'it''s',NULL,NULL,'a',X'6162','2019-03-04 05:06:07.123456',false,1000000000,1.5,'x',NULL`, newSQL)
+
+	var cases = []struct {
+		dialect  string
+		expected string
+	}{
+		{MYSQL, `SELECT a FROM t WHERE b='it''s \\a' AND c=X'6162' AND d='2019-03-04 05:06:07.123456' AND e=true`},
+		{POSTGRES, `SELECT a FROM t WHERE b='it''s \a' AND c='\x6162' AND d='2019-03-04 05:06:07.123456+08:00' AND e=true`},
+		{SQLITE, `SELECT a FROM t WHERE b='it''s \a' AND c=X'6162' AND d='2019-03-04 05:06:07.123456' AND e=1`},
+		{MSSQL, `SELECT a FROM t WHERE b=N'it''s \a' AND c=0x6162 AND d='2019-03-04T05:06:07.123' AND e=1`},
+		{ORACLE, `SELECT a FROM t WHERE b='it''s \a' AND c=HEXTORAW('6162') AND ` +
+			`d=TO_TIMESTAMP('2019-03-04 05:06:07.123456000', 'YYYY-MM-DD HH24:MI:SS.FF9') AND e=1`},
+	}
+
+	for _, c := range cases {
+		newSQL, err = Dialect(c.dialect).Select("a").From("t").
+			Where(Eq{"b": `it's \a`}.And(Eq{"c": []byte("ab")}, Eq{"d": tm}, Eq{"e": true})).
+			ToBoundSQL()
+		assert.NoError(t, err)
+		assert.EqualValues(t, c.expected, newSQL, c.dialect)
+	}
+
+	// slices are arrays of Postgres, which the others don't have
+	newSQL, err = Postgres().Select("a").From("t").Where(InArray("b", []int{1, 2}).And(Expr("c=?", []string{"it's"}))).ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE b=ANY(ARRAY[1,2]) AND (c=ARRAY['it''s'])", newSQL)
+
+	_, err = MySQL().Select("a").From("t").Where(Expr("b=?", []int{1, 2})).ToBoundSQL()
+	assert.EqualError(t, err, ErrNotSupportType.Error())
+
+	_, err = ConvertToBoundSQL("?", []interface{}{[2]string{"a", "b"}})
+	assert.EqualError(t, err, ErrNotSupportType.Error())
+}
@@ -7,8 +7,7 @@ package builder
 import (
 	sql2 "database/sql"
 	"fmt"
-	"reflect"
-	"time"
+	"strings"
 )
 
 func condToSQL(cond Cond) (string, []interface{}, error) {
@@ -20,7 +19,18 @@ func condToSQL(cond Cond) (string, []interface{}, error) {
 	if err := cond.WriteTo(w); err != nil {
 		return "", nil, err
 	}
-	return w.writer.String(), w.args, nil
+	if err := checkParams(w.args); err != nil {
+		return "", nil, err
+	}
+
+	// placeholders are kept, but ?? is unescaped as Builder.ToSQL does
+	sql, err := convertPlaceholders(w.writer.String(), "", func(n int) string {
+		return "?"
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return sql, w.args, nil
 }
 
 func condToBoundSQL(cond Cond) (string, error) {
@@ -57,100 +67,224 @@ func ToBoundSQL(cond interface{}) (string, error) {
 	return "", ErrNotSupportType
 }
 
-func noSQLQuoteNeeded(a interface{}) bool {
-	switch a.(type) {
-	case int, int8, int16, int32, int64:
-		return true
-	case uint, uint8, uint16, uint32, uint64:
-		return true
-	case float32, float64:
-		return true
-	case bool:
-		return true
-	case string:
-		return false
-	case time.Time, *time.Time:
-		return false
-	}
-
-	t := reflect.TypeOf(a)
-	switch t.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return true
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return true
-	case reflect.Float32, reflect.Float64:
-		return true
-	case reflect.Bool:
-		return true
-	case reflect.String:
-		return false
-	}
-
-	return false
+// ConvertToBoundSQL will convert SQL and args to a bound SQL. Question marks in string literals,
+// quoted identifiers and comments are kept, ?? is written as a literal question mark
+func ConvertToBoundSQL(sql string, args []interface{}) (string, error) {
+	return convertToBoundSQL(sql, "", args, formatLiteral)
 }
 
-// ConvertToBoundSQL will convert SQL and args to a bound SQL
-func ConvertToBoundSQL(sql string, args []interface{}) (string, error) {
+func convertToBoundSQL(sql, family string, args []interface{}, format func(interface{}) (string, error)) (string, error) {
+	return replacePlaceholders(sql, family, func(n int) (string, error) {
+		if len(args) < n {
+			return "", ErrNeedMoreArguments
+		}
+
+		arg := args[n-1]
+		if namedArg, ok := arg.(sql2.NamedArg); ok {
+			arg = namedArg.Value
+		}
+		if _, ok := arg.(Param); ok {
+			return "", ErrUnboundParam
+		}
+		return format(arg)
+	})
+}
+
+// ConvertPlaceholder replaces ? to $1, $2 ... or :1, :2 ... according prefix. Question marks in
+// string literals, quoted identifiers and comments are kept, ?? is written as a literal question mark
+func ConvertPlaceholder(sql, prefix string) (string, error) {
+	return convertPlaceholders(sql, "", func(n int) string {
+		return fmt.Sprintf("%v%d", prefix, n)
+	})
+}
+
+// convertPlaceholders replaces the n-th placeholder to placeholder(n)
+func convertPlaceholders(sql, family string, placeholder func(n int) string) (string, error) {
+	return replacePlaceholders(sql, family, func(n int) (string, error) {
+		return placeholder(n), nil
+	})
+}
+
+// replacePlaceholders replaces the n-th ? to replace(n). String literals, quoted identifiers,
+// comments and dollar-quoted strings of the dialect family are skipped, and ?? is unescaped to ?
+func replacePlaceholders(sql, family string, replace func(n int) (string, error)) (string, error) {
 	buf := StringBuilder{}
-	var i, j, start int
-	for ; i < len(sql); i++ {
-		if sql[i] == '?' {
-			_, err := buf.WriteString(sql[start:i])
-			if err != nil {
-				return "", err
-			}
-			start = i + 1
+	var n, start int
+	for i := 0; i < len(sql); i++ {
+		if end, ok := skipLiteral(sql, family, i); ok {
+			i = end
+			continue
+		}
 
-			if len(args) == j {
-				return "", ErrNeedMoreArguments
+		switch sql[i] {
+		case '?':
+			if _, err := buf.WriteString(sql[start:i]); err != nil {
+				return "", err
 			}
 
-			arg := args[j]
-			if namedArg, ok := arg.(sql2.NamedArg); ok {
-				arg = namedArg.Value
+			if strings.HasPrefix(sql[i:], "??") {
+				if err := buf.WriteByte('?'); err != nil {
+					return "", err
+				}
+				i++
+				start = i + 1
+				continue
 			}
 
-			if noSQLQuoteNeeded(arg) {
-				_, err = fmt.Fprint(&buf, arg)
-			} else {
-				_, err = fmt.Fprintf(&buf, "'%v'", arg)
-			}
+			n++
+			placeholder, err := replace(n)
 			if err != nil {
 				return "", err
 			}
-			j = j + 1
+			if _, err := buf.WriteString(placeholder); err != nil {
+				return "", err
+			}
+			start = i + 1
 		}
 	}
-	_, err := buf.WriteString(sql[start:])
-	if err != nil {
+
+	if _, err := buf.WriteString(sql[start:]); err != nil {
 		return "", err
 	}
+
 	return buf.String(), nil
 }
 
-// ConvertPlaceholder replaces ? to $1, $2 ... or :1, :2 ... according prefix
-func ConvertPlaceholder(sql, prefix string) (string, error) {
+// namedPlaceholders replaces named placeholders like :name and @name to ?, and returns their
+// names in order. Casts like ::int, variables like @@var and escaped ?? are kept, positional
+// is true if there are ? placeholders already
+func namedPlaceholders(sql, family string) (converted string, names []string, positional bool) {
 	buf := StringBuilder{}
-	var i, j, start int
-	for ; i < len(sql); i++ {
-		if sql[i] == '?' {
-			if _, err := buf.WriteString(sql[start:i]); err != nil {
-				return "", err
-			}
+	var start int
+	for i := 0; i < len(sql); i++ {
+		if end, ok := skipLiteral(sql, family, i); ok {
+			i = end
+			continue
+		}
 
-			start = i + 1
-			j = j + 1
+		switch c := sql[i]; c {
+		case '?':
+			if strings.HasPrefix(sql[i:], "??") {
+				i++
+			} else {
+				positional = true
+			}
+		case ':', '@':
+			if i+1 < len(sql) && sql[i+1] == c {
+				i++
+				continue
+			}
+			if i > 0 && isIdentifierByte(sql[i-1]) {
+				continue
+			}
 
-			if _, err := buf.WriteString(fmt.Sprintf("%v%d", prefix, j)); err != nil {
-				return "", err
+			j := i + 1
+			for j < len(sql) && isIdentifierByte(sql[j]) && sql[j] != '$' {
+				j++
+			}
+			// a name can't start with a digit, e.g. :1 of Oracle
+			if j == i+1 || (sql[i+1] >= '0' && sql[i+1] <= '9') {
+				continue
 			}
+
+			buf.WriteString(sql[start:i])
+			buf.WriteByte('?')
+			names = append(names, sql[i+1:j])
+			start = j
+			i = j - 1
 		}
 	}
+	buf.WriteString(sql[start:])
+	return buf.String(), names, positional
+}
 
-	if _, err := buf.WriteString(sql[start:]); err != nil {
-		return "", err
+// skipLiteral returns the position of the last byte of the string literal, quoted identifier,
+// comment or dollar-quoted string which starts at i, ok is false if there is none. Brackets of
+// MSSQL and escape strings of Postgres are taken by their dialect family only
+func skipLiteral(sql, family string, i int) (end int, ok bool) {
+	switch sql[i] {
+	case '\'', '"', '`':
+		// strings of MySQL escape characters by backslashes
+		return skipQuoted(sql, i, sql[i], family == MYSQL && sql[i] != '`'), true
+	case '[':
+		if family == MSSQL {
+			return skipQuoted(sql, i, ']', false), true
+		}
+	case 'E', 'e':
+		// escape strings of Postgres like E'it\'s'
+		if family == POSTGRES && i+1 < len(sql) && sql[i+1] == '\'' && (i == 0 || !isIdentifierByte(sql[i-1])) {
+			return skipQuoted(sql, i+1, '\'', true), true
+		}
+	case '-':
+		if strings.HasPrefix(sql[i:], "--") {
+			return skipUntil(sql, i+2, "\n"), true
+		}
+	case '/':
+		if strings.HasPrefix(sql[i:], "/*") {
+			return skipUntil(sql, i+2, "*/"), true
+		}
+	case '$':
+		if tag := dollarTag(sql, i); len(tag) > 0 {
+			return skipUntil(sql, i+len(tag), tag), true
+		}
 	}
+	return i, false
+}
 
-	return buf.String(), nil
+// skipQuoted returns the position of the quote which closes the one at i, a closing quote
+// is escaped by doubling it, and characters are escaped by backslashes as well if backslash
+func skipQuoted(sql string, i int, quote byte, backslash bool) int {
+	for j := i + 1; j < len(sql); j++ {
+		if sql[j] == '\\' && backslash {
+			j++
+			continue
+		}
+		if sql[j] != quote {
+			continue
+		}
+		if j+1 < len(sql) && sql[j+1] == quote {
+			j++
+			continue
+		}
+		return j
+	}
+	return len(sql) - 1
+}
+
+// skipUntil returns the position of the last byte of the first end since i,
+// or the end of sql if it's not found
+func skipUntil(sql string, i int, end string) int {
+	idx := strings.Index(sql[i:], end)
+	if idx < 0 {
+		return len(sql) - 1
+	}
+	return i + idx + len(end) - 1
+}
+
+// dollarTag returns the tag like $$ or $tag$ which opens a dollar-quoted string of Postgres at i
+func dollarTag(sql string, i int) string {
+	// $ could be a part of an identifier
+	if i > 0 && isIdentifierByte(sql[i-1]) {
+		return ""
+	}
+
+	for j := i + 1; j < len(sql); j++ {
+		switch c := sql[j]; {
+		case c == '$':
+			return sql[i : j+1]
+		case c >= '0' && c <= '9':
+			// $1 is a positional parameter
+			if j == i+1 {
+				return ""
+			}
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80:
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
 }
@@ -23,6 +23,55 @@ func TestPlaceholderConverter(t *testing.T) {
 	newSQL, err := ConvertPlaceholder(placeholderConverterSQL, "$")
 	assert.NoError(t, err)
 	assert.EqualValues(t, placeholderConvertedSQL, newSQL)
+
+	newSQL, err = ConvertPlaceholder(`SELECT 'what?', "b?", `+"`c?`"+`, 'it''s?' FROM t -- comment?
+WHERE a=? /* comment? */ AND b ??| ? AND c=$$ dollar? $$ AND d=$tag$ $$? $tag$ AND e$f=? AND g='unclosed?`, "$")
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT 'what?', "b?", `+"`c?`"+`, 'it''s?' FROM t -- comment?
+WHERE a=$1 /* comment? */ AND b ?| $2 AND c=$$ dollar? $$ AND d=$tag$ $$? $tag$ AND e$f=$3 AND g='unclosed?`, newSQL)
+
+	newSQL, err = ConvertPlaceholder("SELECT a FROM t WHERE b=$1 AND c=? -- unclosed?", ":")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE b=$1 AND c=:1 -- unclosed?", newSQL)
+
+	sql, args, err := Postgres().Select("a").From("t").Where(Expr("b ??| array['x?']").And(Eq{"c": 1})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE (b ?| array['x?']) AND c=$1", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	sql, _, err = Select("a").From("t").Where(Expr("b ?? c")).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT a FROM t WHERE b ? c", sql)
+	sql, args, err = ToSQL(Expr("b ?? c AND d=?", 1))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "b ? c AND d=?", sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	// backslashes escape quotes in strings of MySQL only
+	sql, args, err = MySQL().Select("a").From("t").Where(Expr(`b='it\'s :x?' AND c=:c`, map[string]interface{}{"c": 1})).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT a FROM t WHERE b='it\'s :x?' AND c=?`, sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	bound, err := MySQL().Select("a").From("t").Where(Expr(`b='it\'s?' AND c=?`, 1)).ToBoundSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT a FROM t WHERE b='it\'s?' AND c=1`, bound)
+
+	sql, _, err = Postgres().Select("a").From("t").Where(Expr(`b='a\' AND c=?`, 1)).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT a FROM t WHERE b='a\' AND c=$1`, sql)
+
+	// escape strings of Postgres take backslashes after E
+	sql, args, err = Postgres().Select("a").From("t").Where(Expr(`b=E'it\'s?' AND c=?`, 1)).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, `SELECT a FROM t WHERE b=E'it\'s?' AND c=$1`, sql)
+	assert.EqualValues(t, []interface{}{1}, args)
+
+	// brackets quote identifiers of MSSQL
+	sql, args, err = MsSQL().Select("[what?]").From("t").Where(Eq{"[b]": 1}).ToSQL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT [what?] FROM t WHERE [b]=@p1", sql)
+	assert.EqualValues(t, 1, len(args))
 }
 
 func BenchmarkPlaceholderConverter(b *testing.B) {
@@ -44,6 +93,10 @@ func TestBoundSQLConverter(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualValues(t, ErrNeedMoreArguments, err)
 
+	newSQL, err = ConvertToBoundSQL("SELECT 'a?' FROM t WHERE b=? AND c ?? ? /* d=? */", []interface{}{"what?", 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "SELECT 'a?' FROM t WHERE b='what?' AND c ? 2 /* d=? */", newSQL)
+
 	newSQL, err = ToBoundSQL(Select("id").From("table").Where(In("a", 1, 2)))
 	assert.NoError(t, err)
 	assert.EqualValues(t, "SELECT id FROM table WHERE a IN (1,2)", newSQL)